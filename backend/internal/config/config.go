@@ -37,6 +37,17 @@ const (
 	ConnectionPoolIsolationAccountProxy = "account_proxy"
 )
 
+// 不支持模型的处理策略常量
+// 用于控制请求模型在分组内没有任何账号支持时的网关行为
+const (
+	// UnsupportedModelActionSchedule: 保持原有调度流程，最终由调度返回 503（默认）
+	UnsupportedModelActionSchedule = "schedule"
+	// UnsupportedModelActionReject: 在入口直接返回 404，不进入排队与调度
+	UnsupportedModelActionReject = "reject"
+	// UnsupportedModelActionFallback: 改写为 unsupported_model_fallback 指定的模型后继续调度
+	UnsupportedModelActionFallback = "fallback"
+)
+
 type Config struct {
 	Server       ServerConfig               `mapstructure:"server"`
 	CORS         CORSConfig                 `mapstructure:"cors"`
@@ -201,6 +212,9 @@ type CircuitBreakerConfig struct {
 type ConcurrencyConfig struct {
 	// PingInterval: 并发等待期间的 SSE ping 间隔（秒）
 	PingInterval int `mapstructure:"ping_interval"`
+	// SoftLimitPercent: 账号并发软上限（占 MaxConcurrency 的百分比，0 表示关闭）
+	// 获取槽位后并发数达到该比例时记录预警，但不会拒绝请求
+	SoftLimitPercent int `mapstructure:"soft_limit_percent"`
 }
 
 // GatewayConfig API网关相关配置
@@ -257,6 +271,11 @@ type GatewayConfig struct {
 	// 是否允许对部分 400 错误触发 failover（默认关闭以避免改变语义）
 	FailoverOn400 bool `mapstructure:"failover_on_400"`
 
+	// UnsupportedModelAction: 分组内无账号支持请求模型时的处理策略（schedule/reject/fallback）
+	UnsupportedModelAction string `mapstructure:"unsupported_model_action"`
+	// UnsupportedModelFallback: fallback 策略下改写使用的默认模型
+	UnsupportedModelFallback string `mapstructure:"unsupported_model_fallback"`
+
 	// Scheduling: 账号调度相关配置
 	Scheduling GatewaySchedulingConfig `mapstructure:"scheduling"`
 }
@@ -375,6 +394,10 @@ type RedisConfig struct {
 	PoolSize int `mapstructure:"pool_size"`
 	// MinIdleConns: 最小空闲连接数，保持热连接减少冷启动延迟
 	MinIdleConns int `mapstructure:"min_idle_conns"`
+	// ClusterMode: 是否运行在 Redis Cluster 之上。
+	// 开启后使用 ClusterClient 连接（Host/Port 作为种子节点，DB 必须为 0），
+	// 多键 Lua 脚本使用 hash tag 保证键位于同一 slot。
+	ClusterMode bool `mapstructure:"cluster_mode"`
 }
 
 func (r *RedisConfig) Address() string {
@@ -399,6 +422,15 @@ type OpsConfig struct {
 
 	// Pre-aggregation configuration.
 	Aggregation OpsAggregationConfig `mapstructure:"aggregation"`
+
+	// AlertWebhook posts fired alert events to an external endpoint.
+	AlertWebhook OpsAlertWebhookConfig `mapstructure:"alert_webhook"`
+}
+
+type OpsAlertWebhookConfig struct {
+	// URL receives a JSON POST for each fired alert event (empty disables webhook delivery).
+	URL            string `mapstructure:"url"`
+	TimeoutSeconds int    `mapstructure:"timeout_seconds"`
 }
 
 type OpsCleanupConfig struct {
@@ -684,6 +716,7 @@ func setDefaults() {
 	viper.SetDefault("redis.write_timeout_seconds", 3)
 	viper.SetDefault("redis.pool_size", 128)
 	viper.SetDefault("redis.min_idle_conns", 10)
+	viper.SetDefault("redis.cluster_mode", false)
 
 	// Ops (vNext)
 	viper.SetDefault("ops.enabled", true)
@@ -698,6 +731,8 @@ func setDefaults() {
 	viper.SetDefault("ops.metrics_collector_cache.enabled", true)
 	// TTL should be slightly larger than collection interval (1m) to maximize cross-replica cache hits.
 	viper.SetDefault("ops.metrics_collector_cache.ttl", 65*time.Second)
+	viper.SetDefault("ops.alert_webhook.url", "")
+	viper.SetDefault("ops.alert_webhook.timeout_seconds", 10)
 
 	// JWT
 	viper.SetDefault("jwt.secret", "")
@@ -759,6 +794,8 @@ func setDefaults() {
 	viper.SetDefault("gateway.log_upstream_error_body_max_bytes", 2048)
 	viper.SetDefault("gateway.inject_beta_for_apikey", false)
 	viper.SetDefault("gateway.failover_on_400", false)
+	viper.SetDefault("gateway.unsupported_model_action", UnsupportedModelActionSchedule)
+	viper.SetDefault("gateway.unsupported_model_fallback", "")
 	viper.SetDefault("gateway.max_body_size", int64(100*1024*1024))
 	viper.SetDefault("gateway.connection_pool_isolation", ConnectionPoolIsolationAccountProxy)
 	// HTTP 上游连接池配置（针对 5000+ 并发用户优化）
@@ -788,6 +825,7 @@ func setDefaults() {
 	viper.SetDefault("gateway.scheduling.outbox_backlog_rebuild_rows", 10000)
 	viper.SetDefault("gateway.scheduling.full_rebuild_interval_seconds", 300)
 	viper.SetDefault("concurrency.ping_interval", 10)
+	viper.SetDefault("concurrency.soft_limit_percent", 0)
 
 	// TokenRefresh
 	viper.SetDefault("token_refresh.enabled", true)
@@ -917,6 +955,9 @@ func (c *Config) Validate() error {
 	if c.Redis.MinIdleConns > c.Redis.PoolSize {
 		return fmt.Errorf("redis.min_idle_conns cannot exceed redis.pool_size")
 	}
+	if c.Redis.ClusterMode && c.Redis.DB != 0 {
+		return fmt.Errorf("redis.db must be 0 when redis.cluster_mode is enabled")
+	}
 	if c.Dashboard.Enabled {
 		if c.Dashboard.StatsFreshTTLSeconds <= 0 {
 			return fmt.Errorf("dashboard_cache.stats_fresh_ttl_seconds must be positive")
@@ -1000,6 +1041,16 @@ func (c *Config) Validate() error {
 				ConnectionPoolIsolationProxy, ConnectionPoolIsolationAccount, ConnectionPoolIsolationAccountProxy)
 		}
 	}
+	switch strings.TrimSpace(c.Gateway.UnsupportedModelAction) {
+	case "", UnsupportedModelActionSchedule, UnsupportedModelActionReject:
+	case UnsupportedModelActionFallback:
+		if strings.TrimSpace(c.Gateway.UnsupportedModelFallback) == "" {
+			return fmt.Errorf("gateway.unsupported_model_fallback is required when gateway.unsupported_model_action is %s", UnsupportedModelActionFallback)
+		}
+	default:
+		return fmt.Errorf("gateway.unsupported_model_action must be one of: %s/%s/%s",
+			UnsupportedModelActionSchedule, UnsupportedModelActionReject, UnsupportedModelActionFallback)
+	}
 	if c.Gateway.MaxIdleConns <= 0 {
 		return fmt.Errorf("gateway.max_idle_conns must be positive")
 	}
@@ -1103,9 +1154,20 @@ func (c *Config) Validate() error {
 	if c.Ops.Cleanup.Enabled && strings.TrimSpace(c.Ops.Cleanup.Schedule) == "" {
 		return fmt.Errorf("ops.cleanup.schedule is required when ops.cleanup.enabled=true")
 	}
+	if strings.TrimSpace(c.Ops.AlertWebhook.URL) != "" {
+		if err := ValidateAbsoluteHTTPURL(c.Ops.AlertWebhook.URL); err != nil {
+			return fmt.Errorf("ops.alert_webhook.url invalid: %w", err)
+		}
+	}
+	if c.Ops.AlertWebhook.TimeoutSeconds <= 0 {
+		return fmt.Errorf("ops.alert_webhook.timeout_seconds must be positive")
+	}
 	if c.Concurrency.PingInterval < 5 || c.Concurrency.PingInterval > 30 {
 		return fmt.Errorf("concurrency.ping_interval must be between 5-30 seconds")
 	}
+	if c.Concurrency.SoftLimitPercent < 0 || c.Concurrency.SoftLimitPercent > 100 {
+		return fmt.Errorf("concurrency.soft_limit_percent must be between 0-100")
+	}
 	return nil
 }
 
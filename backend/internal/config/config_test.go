@@ -280,3 +280,27 @@ func TestValidateDashboardAggregationBackfillMaxDays(t *testing.T) {
 		t.Fatalf("Validate() expected backfill_max_days error, got: %v", err)
 	}
 }
+
+func TestValidateRedisClusterModeRequiresDBZero(t *testing.T) {
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	cfg.Redis.ClusterMode = true
+	cfg.Redis.DB = 1
+	err = cfg.Validate()
+	if err == nil {
+		t.Fatalf("Validate() expected error for cluster_mode with non-zero db, got nil")
+	}
+	if !strings.Contains(err.Error(), "redis.db") {
+		t.Fatalf("Validate() expected redis.db error, got: %v", err)
+	}
+
+	cfg.Redis.DB = 0
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+}
@@ -571,9 +571,12 @@ func (h *OpsHandler) ListAlertEvents(c *gin.Context) {
 	}
 
 	// Optional global filter support (platform/group/time range).
-	if platform := strings.TrimSpace(c.Query("platform")); platform != "" {
-		filter.Platform = platform
+	platform, validPlatform := parseOpsPlatformParam(c)
+	if !validPlatform {
+		response.BadRequest(c, "Invalid platform")
+		return
 	}
+	filter.Platform = platform
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
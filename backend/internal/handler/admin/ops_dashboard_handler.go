@@ -29,10 +29,15 @@ func (h *OpsHandler) GetDashboardOverview(c *gin.Context) {
 		return
 	}
 
+	platform, validPlatform := parseOpsPlatformParam(c)
+	if !validPlatform {
+		response.BadRequest(c, "Invalid platform")
+		return
+	}
 	filter := &service.OpsDashboardFilter{
 		StartTime: startTime,
 		EndTime:   endTime,
-		Platform:  strings.TrimSpace(c.Query("platform")),
+		Platform:  platform,
 		QueryMode: parseOpsQueryMode(c),
 	}
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
@@ -70,10 +75,15 @@ func (h *OpsHandler) GetDashboardThroughputTrend(c *gin.Context) {
 		return
 	}
 
+	platform, validPlatform := parseOpsPlatformParam(c)
+	if !validPlatform {
+		response.BadRequest(c, "Invalid platform")
+		return
+	}
 	filter := &service.OpsDashboardFilter{
 		StartTime: startTime,
 		EndTime:   endTime,
-		Platform:  strings.TrimSpace(c.Query("platform")),
+		Platform:  platform,
 		QueryMode: parseOpsQueryMode(c),
 	}
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
@@ -112,10 +122,15 @@ func (h *OpsHandler) GetDashboardLatencyHistogram(c *gin.Context) {
 		return
 	}
 
+	platform, validPlatform := parseOpsPlatformParam(c)
+	if !validPlatform {
+		response.BadRequest(c, "Invalid platform")
+		return
+	}
 	filter := &service.OpsDashboardFilter{
 		StartTime: startTime,
 		EndTime:   endTime,
-		Platform:  strings.TrimSpace(c.Query("platform")),
+		Platform:  platform,
 		QueryMode: parseOpsQueryMode(c),
 	}
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
@@ -153,10 +168,15 @@ func (h *OpsHandler) GetDashboardErrorTrend(c *gin.Context) {
 		return
 	}
 
+	platform, validPlatform := parseOpsPlatformParam(c)
+	if !validPlatform {
+		response.BadRequest(c, "Invalid platform")
+		return
+	}
 	filter := &service.OpsDashboardFilter{
 		StartTime: startTime,
 		EndTime:   endTime,
-		Platform:  strings.TrimSpace(c.Query("platform")),
+		Platform:  platform,
 		QueryMode: parseOpsQueryMode(c),
 	}
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
@@ -195,10 +215,15 @@ func (h *OpsHandler) GetDashboardErrorDistribution(c *gin.Context) {
 		return
 	}
 
+	platform, validPlatform := parseOpsPlatformParam(c)
+	if !validPlatform {
+		response.BadRequest(c, "Invalid platform")
+		return
+	}
 	filter := &service.OpsDashboardFilter{
 		StartTime: startTime,
 		EndTime:   endTime,
-		Platform:  strings.TrimSpace(c.Query("platform")),
+		Platform:  platform,
 		QueryMode: parseOpsQueryMode(c),
 	}
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
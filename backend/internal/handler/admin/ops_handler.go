@@ -86,11 +86,7 @@ func (h *OpsHandler) GetErrorLogs(c *gin.Context) {
 		return
 	}
 
-	page, pageSize := response.ParsePagination(c)
-	// Ops list can be larger than standard admin tables.
-	if pageSize > 500 {
-		pageSize = 500
-	}
+	page, pageSize := parseOpsErrorLogPagination(c)
 
 	startTime, endTime, err := parseOpsTimeRange(c, "1h")
 	if err != nil {
@@ -98,7 +94,7 @@ func (h *OpsHandler) GetErrorLogs(c *gin.Context) {
 		return
 	}
 
-	filter := &service.OpsErrorLogFilter{Page: page, PageSize: pageSize}
+	filter := &service.OpsErrorLogFilter{Page: page, PageSize: pageSize, SkipTotal: parseOpsSkipTotal(c)}
 
 	if !startTime.IsZero() {
 		filter.StartTime = &startTime
@@ -119,9 +115,12 @@ func (h *OpsHandler) GetErrorLogs(c *gin.Context) {
 		filter.Phase = ""
 	}
 
-	if platform := strings.TrimSpace(c.Query("platform")); platform != "" {
-		filter.Platform = platform
+	platform, validPlatform := parseOpsPlatformParam(c)
+	if !validPlatform {
+		response.BadRequest(c, "Invalid platform")
+		return
 	}
+	filter.Platform = platform
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
@@ -190,17 +189,14 @@ func (h *OpsHandler) ListRequestErrors(c *gin.Context) {
 		return
 	}
 
-	page, pageSize := response.ParsePagination(c)
-	if pageSize > 500 {
-		pageSize = 500
-	}
+	page, pageSize := parseOpsErrorLogPagination(c)
 	startTime, endTime, err := parseOpsTimeRange(c, "1h")
 	if err != nil {
 		response.BadRequest(c, err.Error())
 		return
 	}
 
-	filter := &service.OpsErrorLogFilter{Page: page, PageSize: pageSize}
+	filter := &service.OpsErrorLogFilter{Page: page, PageSize: pageSize, SkipTotal: parseOpsSkipTotal(c)}
 	if !startTime.IsZero() {
 		filter.StartTime = &startTime
 	}
@@ -220,9 +216,12 @@ func (h *OpsHandler) ListRequestErrors(c *gin.Context) {
 		filter.Phase = ""
 	}
 
-	if platform := strings.TrimSpace(c.Query("platform")); platform != "" {
-		filter.Platform = platform
+	platform, validPlatform := parseOpsPlatformParam(c)
+	if !validPlatform {
+		response.BadRequest(c, "Invalid platform")
+		return
 	}
+	filter.Platform = platform
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
@@ -320,10 +319,7 @@ func (h *OpsHandler) ListRequestErrorUpstreamErrors(c *gin.Context) {
 		return
 	}
 
-	page, pageSize := response.ParsePagination(c)
-	if pageSize > 500 {
-		pageSize = 500
-	}
+	page, pageSize := parseOpsErrorLogPagination(c)
 
 	// Keep correlation window wide enough so linked upstream errors
 	// are discoverable even when UI defaults to 1h elsewhere.
@@ -333,7 +329,7 @@ func (h *OpsHandler) ListRequestErrorUpstreamErrors(c *gin.Context) {
 		return
 	}
 
-	filter := &service.OpsErrorLogFilter{Page: page, PageSize: pageSize}
+	filter := &service.OpsErrorLogFilter{Page: page, PageSize: pageSize, SkipTotal: parseOpsSkipTotal(c)}
 	if !startTime.IsZero() {
 		filter.StartTime = &startTime
 	}
@@ -346,9 +342,12 @@ func (h *OpsHandler) ListRequestErrorUpstreamErrors(c *gin.Context) {
 	filter.Source = strings.TrimSpace(c.Query("error_source"))
 	filter.Query = strings.TrimSpace(c.Query("q"))
 
-	if platform := strings.TrimSpace(c.Query("platform")); platform != "" {
-		filter.Platform = platform
+	platform, validPlatform := parseOpsPlatformParam(c)
+	if !validPlatform {
+		response.BadRequest(c, "Invalid platform")
+		return
 	}
+	filter.Platform = platform
 
 	// Prefer exact match on request_id; if missing, fall back to client_request_id.
 	if requestID != "" {
@@ -475,17 +474,14 @@ func (h *OpsHandler) ListUpstreamErrors(c *gin.Context) {
 		return
 	}
 
-	page, pageSize := response.ParsePagination(c)
-	if pageSize > 500 {
-		pageSize = 500
-	}
+	page, pageSize := parseOpsErrorLogPagination(c)
 	startTime, endTime, err := parseOpsTimeRange(c, "1h")
 	if err != nil {
 		response.BadRequest(c, err.Error())
 		return
 	}
 
-	filter := &service.OpsErrorLogFilter{Page: page, PageSize: pageSize}
+	filter := &service.OpsErrorLogFilter{Page: page, PageSize: pageSize, SkipTotal: parseOpsSkipTotal(c)}
 	if !startTime.IsZero() {
 		filter.StartTime = &startTime
 	}
@@ -499,9 +495,12 @@ func (h *OpsHandler) ListUpstreamErrors(c *gin.Context) {
 	filter.Source = strings.TrimSpace(c.Query("error_source"))
 	filter.Query = strings.TrimSpace(c.Query("q"))
 
-	if platform := strings.TrimSpace(c.Query("platform")); platform != "" {
-		filter.Platform = platform
+	platform, validPlatform := parseOpsPlatformParam(c)
+	if !validPlatform {
+		response.BadRequest(c, "Invalid platform")
+		return
 	}
+	filter.Platform = platform
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
 		if err != nil || id <= 0 {
@@ -636,7 +635,12 @@ func (h *OpsHandler) ListRequestDetails(c *gin.Context) {
 	}
 
 	filter.Kind = strings.TrimSpace(c.Query("kind"))
-	filter.Platform = strings.TrimSpace(c.Query("platform"))
+	platform, validPlatform := parseOpsPlatformParam(c)
+	if !validPlatform {
+		response.BadRequest(c, "Invalid platform")
+		return
+	}
+	filter.Platform = platform
 	filter.Model = strings.TrimSpace(c.Query("model"))
 	filter.RequestID = strings.TrimSpace(c.Query("request_id"))
 	filter.Query = strings.TrimSpace(c.Query("q"))
@@ -844,6 +848,50 @@ func (h *OpsHandler) UpdateErrorResolution(c *gin.Context) {
 	response.Success(c, gin.H{"ok": true})
 }
 
+// parseOpsPlatformParam parses the optional ?platform= filter into a canonical platform value.
+// Legacy aliases (e.g. "ai_studio") are mapped; an empty value means "all platforms".
+// Unknown values report ok=false and callers respond 400 "Invalid platform", since stored
+// platforms are normalized and an unknown filter could only ever match nothing.
+func parseOpsPlatformParam(c *gin.Context) (service.Platform, bool) {
+	raw := strings.TrimSpace(c.Query("platform"))
+	if raw == "" {
+		return "", true
+	}
+	return service.ParsePlatform(raw)
+}
+
+// parseOpsSkipTotal reports whether the client opted out of the exact total count (?with_total=false).
+func parseOpsSkipTotal(c *gin.Context) bool {
+	switch strings.ToLower(strings.TrimSpace(c.Query("with_total"))) {
+	case "0", "false", "no":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseOpsErrorLogPagination parses page/page_size (or limit) for error-log lists.
+// Unlike response.ParsePagination it accepts page sizes up to service.OpsErrorLogMaxPageSize,
+// and leaves pageSize at 0 when unset so the service default applies.
+func parseOpsErrorLogPagination(c *gin.Context) (page, pageSize int) {
+	page = 1
+	if v, err := strconv.Atoi(strings.TrimSpace(c.Query("page"))); err == nil && v > 0 {
+		page = v
+	}
+
+	raw := strings.TrimSpace(c.Query("page_size"))
+	if raw == "" {
+		raw = strings.TrimSpace(c.Query("limit"))
+	}
+	if v, err := strconv.Atoi(raw); err == nil && v > 0 {
+		pageSize = v
+		if pageSize > service.OpsErrorLogMaxPageSize {
+			pageSize = service.OpsErrorLogMaxPageSize
+		}
+	}
+	return page, pageSize
+}
+
 func parseOpsTimeRange(c *gin.Context, defaultRange string) (time.Time, time.Time, error) {
 	startStr := strings.TrimSpace(c.Query("start_time"))
 	endStr := strings.TrimSpace(c.Query("end_time"))
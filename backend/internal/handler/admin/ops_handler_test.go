@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOpsSkipTotal(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]bool{
+		"":                     false,
+		"?with_total=true":     false,
+		"?with_total=1":        false,
+		"?with_total=bogus":    false,
+		"?with_total=false":    true,
+		"?with_total=0":        true,
+		"?with_total=No":       true,
+		"?with_total=%20false": true,
+	}
+	for query, want := range cases {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/api/v1/admin/ops/errors"+query, nil)
+		require.Equal(t, want, parseOpsSkipTotal(c), "query %q", query)
+	}
+}
+
+func TestParseOpsPlatformParam(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		query string
+		want  service.Platform
+		ok    bool
+	}{
+		{"", "", true},
+		{"?platform=gemini", "gemini", true},
+		{"?platform=AI_Studio", "gemini", true},
+		{"?platform=google_one", "gemini", true},
+		{"?platform=claude", "anthropic", true},
+		{"?platform=bogus", "", false},
+	}
+	for _, tc := range cases {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/api/v1/admin/ops/errors"+tc.query, nil)
+		got, ok := parseOpsPlatformParam(c)
+		require.Equal(t, tc.ok, ok, "query %q", tc.query)
+		require.Equal(t, tc.want, got, "query %q", tc.query)
+	}
+}
+
+func TestOpsHandler_UnknownPlatformIsBadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewOpsHandler(&service.OpsService{})
+	router := gin.New()
+	router.GET("/errors", h.GetErrorLogs)
+	router.GET("/dashboard/overview", h.GetDashboardOverview)
+
+	for _, path := range []string{"/errors?platform=bogus", "/dashboard/overview?platform=bogus"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		require.Equal(t, http.StatusBadRequest, w.Code, path)
+		require.Contains(t, w.Body.String(), "Invalid platform", path)
+	}
+}
+
+func TestOpsHandler_GetWindowRates_EmptyWindowsIsBadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewOpsHandler(&service.OpsService{})
+	router := gin.New()
+	router.GET("/window-rates", h.GetWindowRates)
+
+	for _, path := range []string{"/window-rates?windows=%20,%20", "/window-rates?windows=,", "/window-rates?windows=2min"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		require.Equal(t, http.StatusBadRequest, w.Code, path)
+		require.Contains(t, w.Body.String(), "Invalid windows", path)
+	}
+}
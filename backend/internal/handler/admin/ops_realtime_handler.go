@@ -34,7 +34,11 @@ func (h *OpsHandler) GetConcurrencyStats(c *gin.Context) {
 		return
 	}
 
-	platformFilter := strings.TrimSpace(c.Query("platform"))
+	platformFilter, validPlatform := parseOpsPlatformParam(c)
+	if !validPlatform {
+		response.BadRequest(c, "Invalid platform")
+		return
+	}
 	var groupID *int64
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
@@ -45,17 +49,19 @@ func (h *OpsHandler) GetConcurrencyStats(c *gin.Context) {
 		groupID = &id
 	}
 
-	platform, group, account, collectedAt, err := h.opsService.GetConcurrencyStats(c.Request.Context(), platformFilter, groupID)
+	platform, group, account, collectedAt, err := h.opsService.GetConcurrencyStats(c.Request.Context(), string(platformFilter), groupID)
 	if err != nil {
 		response.ErrorFrom(c, err)
 		return
 	}
 
 	payload := gin.H{
-		"enabled":  true,
-		"platform": platform,
-		"group":    group,
-		"account":  account,
+		"enabled":              true,
+		"platform":             platform,
+		"group":                group,
+		"account":              account,
+		"soft_limit_crossings": h.opsService.GetConcurrencySoftLimitCrossings(),
+		"in_flight_requests":   h.opsService.GetInFlightRequests(c.Request.Context()),
 	}
 	if collectedAt != nil {
 		payload["timestamp"] = collectedAt.UTC()
@@ -63,6 +69,53 @@ func (h *OpsHandler) GetConcurrencyStats(c *gin.Context) {
 	response.Success(c, payload)
 }
 
+// GetAccountConcurrencyHistory returns hourly peak/avg concurrency per account (capacity planning).
+// GET /api/v1/admin/ops/concurrency/history
+//
+// Query params:
+// - start_time/end_time or time_range: optional (default last 24h)
+// - account_id: optional
+func (h *OpsHandler) GetAccountConcurrencyHistory(c *gin.Context) {
+	if h.opsService == nil {
+		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		return
+	}
+	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	startTime, endTime, err := parseOpsTimeRange(c, "24h")
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	filter := &service.OpsAccountConcurrencyHistoryFilter{
+		StartTime: startTime,
+		EndTime:   endTime,
+	}
+	if v := strings.TrimSpace(c.Query("account_id")); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			response.BadRequest(c, "Invalid account_id")
+			return
+		}
+		filter.AccountID = &id
+	}
+
+	points, err := h.opsService.GetAccountConcurrencyHistory(c.Request.Context(), filter)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{
+		"start_time": startTime.UTC(),
+		"end_time":   endTime.UTC(),
+		"points":     points,
+	})
+}
+
 // GetAccountAvailability returns account availability statistics.
 // GET /api/v1/admin/ops/account-availability
 //
@@ -90,7 +143,11 @@ func (h *OpsHandler) GetAccountAvailability(c *gin.Context) {
 		return
 	}
 
-	platform := strings.TrimSpace(c.Query("platform"))
+	platform, validPlatform := parseOpsPlatformParam(c)
+	if !validPlatform {
+		response.BadRequest(c, "Invalid platform")
+		return
+	}
 	var groupID *int64
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
@@ -101,7 +158,7 @@ func (h *OpsHandler) GetAccountAvailability(c *gin.Context) {
 		groupID = &id
 	}
 
-	platformStats, groupStats, accountStats, collectedAt, err := h.opsService.GetAccountAvailabilityStats(c.Request.Context(), platform, groupID)
+	platformStats, groupStats, accountStats, collectedAt, err := h.opsService.GetAccountAvailabilityStats(c.Request.Context(), string(platform), groupID)
 	if err != nil {
 		response.ErrorFrom(c, err)
 		return
@@ -119,6 +176,113 @@ func (h *OpsHandler) GetAccountAvailability(c *gin.Context) {
 	response.Success(c, payload)
 }
 
+// GetWindowRates returns success/error rates for several trailing windows in one response.
+// GET /api/v1/admin/ops/window-rates
+//
+// Query params:
+// - windows: comma-separated list of 1min|5min|30min|1h (default: 1min,5min,1h)
+func (h *OpsHandler) GetWindowRates(c *gin.Context) {
+	if h.opsService == nil {
+		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		return
+	}
+	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	raw := strings.TrimSpace(c.Query("windows"))
+	if raw == "" {
+		raw = "1min,5min,1h"
+	}
+	var windows []time.Duration
+	for _, part := range strings.Split(raw, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		dur, _, ok := parseOpsRealtimeWindow(part)
+		if !ok {
+			response.BadRequest(c, "Invalid windows")
+			return
+		}
+		windows = append(windows, dur)
+	}
+	// An explicit but empty list (e.g. "windows= , ") is rejected rather than silently
+	// falling back to the service defaults.
+	if len(windows) == 0 {
+		response.BadRequest(c, "Invalid windows")
+		return
+	}
+
+	endTime := time.Now().UTC()
+	rates, err := h.opsService.GetWindowRates(c.Request.Context(), endTime, windows)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{
+		"windows":   rates,
+		"timestamp": endTime,
+	})
+}
+
+// GetWindowBreakdown returns request/error/token counts for one trailing window grouped by platform or model.
+// GET /api/v1/admin/ops/window-breakdown
+//
+// Query params:
+// - by: platform|model (default: platform)
+// - window: 1min|5min|30min|1h (default: 5min)
+func (h *OpsHandler) GetWindowBreakdown(c *gin.Context) {
+	if h.opsService == nil {
+		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		return
+	}
+	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	window := c.Query("window")
+	if strings.TrimSpace(window) == "" {
+		window = "5min"
+	}
+	dur, label, ok := parseOpsRealtimeWindow(window)
+	if !ok {
+		response.BadRequest(c, "Invalid window")
+		return
+	}
+
+	endTime := time.Now().UTC()
+	startTime := endTime.Add(-dur)
+
+	by := strings.ToLower(strings.TrimSpace(c.Query("by")))
+	var (
+		stats map[string]*service.OpsWindowStats
+		err   error
+	)
+	switch by {
+	case "", service.OpsWindowStatsByPlatform:
+		by = service.OpsWindowStatsByPlatform
+		stats, err = h.opsService.GetWindowStatsByProvider(c.Request.Context(), startTime, endTime)
+	case service.OpsWindowStatsByModel:
+		stats, err = h.opsService.GetWindowStatsByModel(c.Request.Context(), startTime, endTime)
+	default:
+		response.BadRequest(c, "Invalid by")
+		return
+	}
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"by":        by,
+		"window":    label,
+		"items":     stats,
+		"timestamp": endTime,
+	})
+}
+
 func parseOpsRealtimeWindow(v string) (time.Duration, string, bool) {
 	switch strings.ToLower(strings.TrimSpace(v)) {
 	case "", "1min", "1m":
@@ -157,7 +321,11 @@ func (h *OpsHandler) GetRealtimeTrafficSummary(c *gin.Context) {
 		return
 	}
 
-	platform := strings.TrimSpace(c.Query("platform"))
+	platform, validPlatform := parseOpsPlatformParam(c)
+	if !validPlatform {
+		response.BadRequest(c, "Invalid platform")
+		return
+	}
 	var groupID *int64
 	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
 		id, err := strconv.ParseInt(v, 10, 64)
@@ -176,7 +344,7 @@ func (h *OpsHandler) GetRealtimeTrafficSummary(c *gin.Context) {
 			Window:    windowLabel,
 			StartTime: startTime,
 			EndTime:   endTime,
-			Platform:  platform,
+			Platform:  string(platform),
 			GroupID:   groupID,
 			QPS:       service.OpsRateSummary{},
 			TPS:       service.OpsRateSummary{},
@@ -444,39 +444,7 @@ func handleQPSWebSocket(parentCtx context.Context, conn *websocket.Conn) {
 
 	closeFrameCh := make(chan []byte, 1)
 
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer cancel()
-
-		conn.SetReadLimit(qpsWSMaxReadBytes)
-		if err := conn.SetReadDeadline(time.Now().Add(qpsWSPongWait)); err != nil {
-			log.Printf("[OpsWS] set read deadline failed: %v", err)
-			return
-		}
-		conn.SetPongHandler(func(string) error {
-			return conn.SetReadDeadline(time.Now().Add(qpsWSPongWait))
-		})
-		conn.SetCloseHandler(func(code int, text string) error {
-			select {
-			case closeFrameCh <- websocket.FormatCloseMessage(code, text):
-			default:
-			}
-			cancel()
-			return nil
-		})
-
-		for {
-			_, _, err := conn.ReadMessage()
-			if err != nil {
-				if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway, websocket.CloseNoStatusReceived) {
-					log.Printf("[OpsWS] read failed: %v", err)
-				}
-				return
-			}
-		}
-	}()
+	wg := startOpsWSReader(conn, cancel, closeFrameCh)
 
 	// Push QPS data every 2 seconds (values are globally cached and refreshed at most once per qpsWSRefreshInterval).
 	pushTicker := time.NewTicker(qpsWSPushInterval)
@@ -545,6 +513,45 @@ func handleQPSWebSocket(parentCtx context.Context, conn *websocket.Conn) {
 	}
 }
 
+// startOpsWSReader reads from conn only to process control frames (Pong/Close).
+// It cancels the connection context once the peer goes away; callers wait on the returned group.
+func startOpsWSReader(conn *websocket.Conn, cancel context.CancelFunc, closeFrameCh chan<- []byte) *sync.WaitGroup {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer cancel()
+
+		conn.SetReadLimit(qpsWSMaxReadBytes)
+		if err := conn.SetReadDeadline(time.Now().Add(qpsWSPongWait)); err != nil {
+			log.Printf("[OpsWS] set read deadline failed: %v", err)
+			return
+		}
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(qpsWSPongWait))
+		})
+		conn.SetCloseHandler(func(code int, text string) error {
+			select {
+			case closeFrameCh <- websocket.FormatCloseMessage(code, text):
+			default:
+			}
+			cancel()
+			return nil
+		})
+
+		for {
+			_, _, err := conn.ReadMessage()
+			if err != nil {
+				if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway, websocket.CloseNoStatusReceived) {
+					log.Printf("[OpsWS] read failed: %v", err)
+				}
+				return
+			}
+		}
+	}()
+	return &wg
+}
+
 func isAllowedOpsWSOrigin(r *http.Request) bool {
 	if r == nil {
 		return false
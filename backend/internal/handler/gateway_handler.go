@@ -104,12 +104,31 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 
 	setOpsRequestContext(c, reqModel, reqStream, body)
 
+	// stream 字段与 Accept 头冲突时以请求体为准，避免上游按错误的格式响应
+	if service.ReconcileStreamAccept(c.Request.Header, reqStream) {
+		log.Printf("Reconciled Accept header with body stream=%v", reqStream)
+	}
+
 	// 验证 model 必填
 	if reqModel == "" {
 		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "model is required")
 		return
 	}
 
+	// 入口校验：分组内无账号支持请求模型时按配置直接拒绝或改写为默认模型
+	if err := h.gatewayService.ResolveUnsupportedModel(c.Request.Context(), apiKey.GroupID, parsedReq); err != nil {
+		if errors.Is(err, service.ErrNoAccountForModel) {
+			h.errorResponse(c, http.StatusNotFound, "not_found_error", err.Error())
+			return
+		}
+		log.Printf("Resolve unsupported model failed: %v", err)
+	}
+	if parsedReq.Model != reqModel {
+		body = parsedReq.Body
+		reqModel = parsedReq.Model
+		setOpsRequestContext(c, reqModel, reqStream, body)
+	}
+
 	// Track if we've started streaming (for error handling)
 	streamStarted := false
 
@@ -473,7 +473,7 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 				ClientRequestID: clientRequestID,
 
 				AccountID: accountID,
-				Platform:  platform,
+				Platform:  service.Platform(platform),
 				Model:     modelName,
 				RequestPath: func() string {
 					if c.Request != nil && c.Request.URL != nil {
@@ -518,7 +518,7 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 				}
 				// Prefer group platform if present (more stable than inferring from path).
 				if apiKey.Group != nil && apiKey.Group.Platform != "" {
-					entry.Platform = apiKey.Group.Platform
+					entry.Platform = service.Platform(apiKey.Group.Platform)
 				}
 			}
 
@@ -589,7 +589,7 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 			ClientRequestID: clientRequestID,
 
 			AccountID: accountID,
-			Platform:  platform,
+			Platform:  service.Platform(platform),
 			Model:     modelName,
 			RequestPath: func() string {
 				if c.Request != nil && c.Request.URL != nil {
@@ -601,7 +601,7 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 			UserAgent: c.GetHeader("User-Agent"),
 
 			ErrorPhase:        phase,
-			ErrorType:         normalizeOpsErrorType(parsed.ErrorType, parsed.Code),
+			ErrorType:         normalizeOpsErrorType(parsed.ErrorType, parsed.Code, parsed.Message),
 			Severity:          classifyOpsSeverity(parsed.ErrorType, status),
 			StatusCode:        status,
 			IsBusinessLimited: isBusinessLimited,
@@ -683,7 +683,7 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 			}
 			// Prefer group platform if present (more stable than inferring from path).
 			if apiKey.Group != nil && apiKey.Group.Platform != "" {
-				entry.Platform = apiKey.Group.Platform
+				entry.Platform = service.Platform(apiKey.Group.Platform)
 			}
 		}
 
@@ -821,7 +821,10 @@ func guessPlatformFromPath(path string) string {
 	}
 }
 
-func normalizeOpsErrorType(errType string, code string) string {
+func normalizeOpsErrorType(errType string, code string, message string) string {
+	if isOpsNoAccountForModelError(errType, message) {
+		return "no_account_for_model"
+	}
 	if errType != "" {
 		return errType
 	}
@@ -856,6 +859,11 @@ func classifyOpsPhase(errType, message, code string) string {
 		return "upstream"
 	case "invalid_request_error":
 		return "request"
+	case "not_found_error":
+		if isOpsNoAccountForModelError(errType, message) {
+			return "routing"
+		}
+		return "request"
 	case "upstream_error", "overloaded_error":
 		return "upstream"
 	case "api_error":
@@ -868,6 +876,11 @@ func classifyOpsPhase(errType, message, code string) string {
 	}
 }
 
+// isOpsNoAccountForModelError 识别入口模型校验拒绝的请求（gateway.unsupported_model_action=reject）
+func isOpsNoAccountForModelError(errType, message string) bool {
+	return errType == "not_found_error" && strings.Contains(strings.ToLower(message), "no available accounts supporting model")
+}
+
 func classifyOpsSeverity(errType string, status int) string {
 	switch errType {
 	case "invalid_request_error", "authentication_error", "billing_error", "subscription_error":
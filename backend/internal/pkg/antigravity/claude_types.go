@@ -46,12 +46,66 @@ type ClaudeTool struct {
 	Description string          `json:"description,omitempty"`  // 标准格式使用
 	InputSchema map[string]any  `json:"input_schema,omitempty"` // 标准格式使用
 	Custom      *CustomToolSpec `json:"custom,omitempty"`       // custom 格式使用
+
+	// rawInputSchema 解码时保留的 input_schema 原始 JSON，作为 schema 清理缓存的键
+	rawInputSchema []byte
+}
+
+// UnmarshalJSON 解码时保留 input_schema 的原始字节，避免清理缓存为计算键重新序列化 schema。
+func (t *ClaudeTool) UnmarshalJSON(data []byte) error {
+	type alias ClaudeTool
+	aux := struct {
+		*alias
+		InputSchema json.RawMessage `json:"input_schema,omitempty"`
+	}{alias: (*alias)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	schema, raw, err := decodeInputSchema(aux.InputSchema)
+	if err != nil {
+		return err
+	}
+	t.InputSchema, t.rawInputSchema = schema, raw
+	return nil
 }
 
 // CustomToolSpec MCP custom 工具规格
 type CustomToolSpec struct {
 	Description string         `json:"description,omitempty"`
 	InputSchema map[string]any `json:"input_schema"`
+
+	// rawInputSchema 同 ClaudeTool.rawInputSchema
+	rawInputSchema []byte
+}
+
+// UnmarshalJSON 解码时保留 input_schema 的原始字节（同 ClaudeTool）。
+func (s *CustomToolSpec) UnmarshalJSON(data []byte) error {
+	type alias CustomToolSpec
+	aux := struct {
+		*alias
+		InputSchema json.RawMessage `json:"input_schema"`
+	}{alias: (*alias)(s)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	schema, raw, err := decodeInputSchema(aux.InputSchema)
+	if err != nil {
+		return err
+	}
+	s.InputSchema, s.rawInputSchema = schema, raw
+	return nil
+}
+
+// decodeInputSchema 解码 input_schema，返回 schema 及其原始字节（缺失或 null 时均为 nil）
+func decodeInputSchema(raw json.RawMessage) (map[string]any, []byte, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil, nil
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, nil, err
+	}
+	return schema, raw, nil
 }
 
 // ClaudeCustomToolSpec 兼容旧命名（MCP custom 工具规格）
@@ -497,6 +497,7 @@ func buildTools(tools []ClaudeTool) []GeminiToolDeclaration {
 
 		var description string
 		var inputSchema map[string]any
+		var rawInputSchema []byte
 
 		// 检查是否为 custom 类型工具 (MCP)
 		if tool.Type == "custom" {
@@ -506,15 +507,17 @@ func buildTools(tools []ClaudeTool) []GeminiToolDeclaration {
 			}
 			description = tool.Custom.Description
 			inputSchema = tool.Custom.InputSchema
+			rawInputSchema = tool.Custom.rawInputSchema
 
 		} else {
 			// 标准格式: 从顶层字段获取
 			description = tool.Description
 			inputSchema = tool.InputSchema
+			rawInputSchema = tool.rawInputSchema
 		}
 
-		// 清理 JSON Schema
-		params := cleanJSONSchema(inputSchema)
+		// 清理 JSON Schema（相同 schema 复用缓存的清理结果）
+		params := cleanJSONSchemaCached(inputSchema, rawInputSchema)
 		// 为 nil schema 提供默认值
 		if params == nil {
 			params = map[string]any{
@@ -545,7 +548,8 @@ func cleanJSONSchema(schema map[string]any) map[string]any {
 	if schema == nil {
 		return nil
 	}
-	cleaned := cleanSchemaValue(schema, "$")
+	// $ref/$defs 会被整体移除，先内联本地引用，避免丢失被引用的分支
+	cleaned := cleanSchemaValue(resolveSchemaRefs(schema), "$")
 	result, ok := cleaned.(map[string]any)
 	if !ok {
 		return nil
@@ -583,6 +587,91 @@ func cleanJSONSchema(schema map[string]any) map[string]any {
 	return result
 }
 
+// maxSchemaRefExpansions 单个 schema 允许内联的 $ref 次数上限，防止引用图指数级展开
+const maxSchemaRefExpansions = 256
+
+// resolveSchemaRefs 将本地 $ref（#/$defs/Name、#/definitions/Name）内联为被引用的定义，
+// 引用节点上的兄弟字段（如 description）覆盖定义中的同名字段。
+// 递归引用或超出展开上限时以 {"type":"OBJECT"} 兜底；非本地引用保持原样，由清理步骤移除。
+func resolveSchemaRefs(schema map[string]any) map[string]any {
+	defs := make(map[string]any)
+	for _, key := range []string{"definitions", "$defs"} {
+		if m, ok := schema[key].(map[string]any); ok {
+			for name, def := range m {
+				defs["#/"+key+"/"+name] = def
+			}
+		}
+	}
+	if len(defs) == 0 {
+		return schema
+	}
+	r := &schemaRefResolver{defs: defs, visiting: make(map[string]bool)}
+	resolved, ok := r.inline(schema).(map[string]any)
+	if !ok {
+		return schema
+	}
+	return resolved
+}
+
+type schemaRefResolver struct {
+	defs       map[string]any
+	visiting   map[string]bool
+	expansions int
+}
+
+func (r *schemaRefResolver) inline(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			if def, found := r.defs[ref]; found {
+				return r.inlineRef(ref, def, v)
+			}
+		}
+		result := make(map[string]any, len(v))
+		for k, val := range v {
+			if k == "$defs" || k == "definitions" {
+				// 定义本身会被清理步骤移除，无需展开
+				result[k] = val
+				continue
+			}
+			result[k] = r.inline(val)
+		}
+		return result
+
+	case []any:
+		result := make([]any, 0, len(v))
+		for _, item := range v {
+			result = append(result, r.inline(item))
+		}
+		return result
+
+	default:
+		return value
+	}
+}
+
+func (r *schemaRefResolver) inlineRef(ref string, def any, node map[string]any) any {
+	if r.visiting[ref] || r.expansions >= maxSchemaRefExpansions {
+		return map[string]any{"type": "OBJECT"}
+	}
+	r.expansions++
+	r.visiting[ref] = true
+	inlined := r.inline(def)
+	delete(r.visiting, ref)
+
+	defMap, ok := inlined.(map[string]any)
+	if !ok {
+		return inlined
+	}
+	for k, val := range node {
+		if k == "$ref" {
+			continue
+		}
+		defMap[k] = r.inline(val)
+	}
+	return defMap
+}
+
 var schemaValidationKeys = map[string]bool{
 	"minLength":         true,
 	"maxLength":         true,
@@ -708,7 +797,10 @@ func cleanSchemaValue(value any, path string) any {
 
 			// 特殊处理 type 字段
 			if k == "type" {
-				result[k] = cleanTypeValue(val)
+				// 非字符串/数组的 type（畸形 schema）直接丢弃，由上层补默认值
+				if typ, ok := cleanTypeValue(val); ok {
+					result[k] = typ
+				}
 				continue
 			}
 
@@ -735,9 +827,42 @@ func cleanSchemaValue(value any, path string) any {
 				continue
 			}
 
+			// properties 的键是用户定义的属性名（可能恰好叫 type/enum/format 等），
+			// 不能按 schema 关键字处理，只清理各属性对应的 schema
+			if k == "properties" {
+				if props, ok := val.(map[string]any); ok {
+					result[k] = cleanProperties(props, path+"."+k)
+					continue
+				}
+			}
+
 			// 递归清理所有值
 			result[k] = cleanSchemaValue(val, path+"."+k)
 		}
+
+		// ARRAY 必须带 items schema，否则 Gemini 校验失败：
+		// 缺失时默认 STRING，元组形式（items 为数组）取第一个 schema
+		if result["type"] == "ARRAY" {
+			result["items"] = arrayItemsSchema(result["items"])
+		}
+
+		// enum：STRING 类型下成员统一转为字符串，无有效成员时整体移除
+		// （仅作用于 schema 节点；properties 下名为 enum 的属性由 cleanProperties 处理）
+		if enum, ok := result["enum"]; ok {
+			if cleanedEnum, ok := cleanEnumValue(enum, result["type"]); ok {
+				result["enum"] = cleanedEnum
+			} else {
+				delete(result, "enum")
+			}
+		}
+
+		// anyOf/oneOf/allOf 会被整体移除，若当前节点自身没有 description，
+		// 从被移除的分支中继承，避免丢失 Gemini 工具调用依赖的字段说明
+		if _, hasDesc := result["description"]; !hasDesc {
+			if desc, ok := combinatorDescription(v); ok {
+				result["description"] = desc
+			}
+		}
 		return result
 
 	case []any:
@@ -753,21 +878,91 @@ func cleanSchemaValue(value any, path string) any {
 	}
 }
 
-// cleanTypeValue 处理 type 字段，转换为大写
-func cleanTypeValue(value any) any {
+// cleanProperties 按属性名逐个清理 properties 中的 schema，属性名本身原样保留
+func cleanProperties(props map[string]any, path string) map[string]any {
+	result := make(map[string]any, len(props))
+	for name, schema := range props {
+		result[name] = cleanSchemaValue(schema, path+"."+name)
+	}
+	return result
+}
+
+// arrayItemsSchema 返回可用的 items schema，无法使用时默认 {"type":"STRING"}
+func arrayItemsSchema(items any) map[string]any {
+	switch v := items.(type) {
+	case map[string]any:
+		return v
+	case []any:
+		if len(v) > 0 {
+			if first, ok := v[0].(map[string]any); ok {
+				return first
+			}
+		}
+	}
+	return map[string]any{"type": "STRING"}
+}
+
+// cleanEnumValue 处理 enum 字段：非数组或清理后为空时返回 ok=false；
+// 类型为 STRING 时将成员转换为字符串（null 与复合值直接丢弃）
+func cleanEnumValue(value any, typ any) ([]any, bool) {
+	members, ok := value.([]any)
+	if !ok {
+		return nil, false
+	}
+	if typ != "STRING" {
+		return members, len(members) > 0
+	}
+	cleaned := make([]any, 0, len(members))
+	for _, m := range members {
+		switch v := m.(type) {
+		case string:
+			cleaned = append(cleaned, v)
+		case float64:
+			cleaned = append(cleaned, strconv.FormatFloat(v, 'f', -1, 64))
+		case bool:
+			cleaned = append(cleaned, strconv.FormatBool(v))
+		case json.Number:
+			cleaned = append(cleaned, v.String())
+		}
+	}
+	return cleaned, len(cleaned) > 0
+}
+
+// combinatorDescription 从 anyOf/oneOf/allOf 分支中取第一个非空 description
+func combinatorDescription(schema map[string]any) (string, bool) {
+	for _, key := range []string{"anyOf", "oneOf", "allOf"} {
+		branches, ok := schema[key].([]any)
+		if !ok {
+			continue
+		}
+		for _, branch := range branches {
+			m, ok := branch.(map[string]any)
+			if !ok {
+				continue
+			}
+			if desc, ok := m["description"].(string); ok && strings.TrimSpace(desc) != "" {
+				return desc, true
+			}
+		}
+	}
+	return "", false
+}
+
+// cleanTypeValue 处理 type 字段，转换为大写；无法识别的 type 值返回 ok=false
+func cleanTypeValue(value any) (string, bool) {
 	switch v := value.(type) {
 	case string:
-		return strings.ToUpper(v)
+		return strings.ToUpper(v), true
 	case []any:
 		// 联合类型 ["string", "null"] -> 取第一个非 null 类型
 		for _, t := range v {
 			if ts, ok := t.(string); ok && ts != "null" {
-				return strings.ToUpper(ts)
+				return strings.ToUpper(ts), true
 			}
 		}
 		// 如果只有 null，返回 STRING
-		return "STRING"
+		return "STRING", true
 	default:
-		return value
+		return "", false
 	}
 }
@@ -242,3 +242,266 @@ func TestBuildTools_CustomTypeTools(t *testing.T) {
 		})
 	}
 }
+
+// TestCleanJSONSchema_PreservesDescriptions 测试 schema 清理过程中 description 不丢失
+func TestCleanJSONSchema_PreservesDescriptions(t *testing.T) {
+	schema := map[string]any{
+		"type":        "object",
+		"description": "root description",
+		"properties": map[string]any{
+			"name": map[string]any{
+				"type":        []any{"string", "null"},
+				"description": "union type collapsed",
+				"minLength":   1,
+			},
+			"ref": map[string]any{
+				"$ref":        "#/$defs/Thing",
+				"description": "ref stripped",
+			},
+			"choice": map[string]any{
+				"anyOf": []any{
+					map[string]any{"type": "null"},
+					map[string]any{"type": "string", "description": "lifted from anyOf"},
+				},
+			},
+			"tags": map[string]any{
+				"type":        "array",
+				"description": "array description",
+				"items": map[string]any{
+					"type":        "object",
+					"description": "item description",
+					"properties": map[string]any{
+						"label": map[string]any{"type": "string", "description": "nested item property"},
+					},
+				},
+			},
+		},
+	}
+
+	cleaned := cleanJSONSchema(schema)
+	props := cleaned["properties"].(map[string]any)
+	tags := props["tags"].(map[string]any)
+	items := tags["items"].(map[string]any)
+
+	cases := map[string]any{
+		"root":         cleaned["description"],
+		"union":        props["name"].(map[string]any)["description"],
+		"ref":          props["ref"].(map[string]any)["description"],
+		"anyOf":        props["choice"].(map[string]any)["description"],
+		"array":        tags["description"],
+		"items":        items["description"],
+		"nested_items": items["properties"].(map[string]any)["label"].(map[string]any)["description"],
+	}
+	expected := map[string]string{
+		"root":         "root description",
+		"union":        "union type collapsed",
+		"ref":          "ref stripped",
+		"anyOf":        "lifted from anyOf",
+		"array":        "array description",
+		"items":        "item description",
+		"nested_items": "nested item property",
+	}
+	for name, want := range expected {
+		if got := cases[name]; got != want {
+			t.Errorf("%s: description = %v, want %q", name, got, want)
+		}
+	}
+}
+
+// TestCleanJSONSchema_ResolvesLocalRefs 测试 $defs 引用被内联而非整体丢弃
+func TestCleanJSONSchema_ResolvesLocalRefs(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"$defs": map[string]any{
+			"Address": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"city": map[string]any{"type": "string"},
+				},
+				"required": []any{"city"},
+			},
+			"Node": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"next": map[string]any{"$ref": "#/$defs/Node"},
+				},
+			},
+		},
+		"properties": map[string]any{
+			"home":  map[string]any{"$ref": "#/$defs/Address", "description": "home address"},
+			"work":  map[string]any{"$ref": "#/$defs/Address"},
+			"chain": map[string]any{"$ref": "#/$defs/Node"},
+		},
+	}
+
+	cleaned := cleanJSONSchema(schema)
+	if _, ok := cleaned["$defs"]; ok {
+		t.Fatalf("$defs should be stripped after resolution")
+	}
+	props := cleaned["properties"].(map[string]any)
+	for _, name := range []string{"home", "work"} {
+		addr, ok := props[name].(map[string]any)
+		if !ok {
+			t.Fatalf("%s: expected inlined object, got %v", name, props[name])
+		}
+		if addr["type"] != "OBJECT" {
+			t.Errorf("%s: type = %v, want OBJECT", name, addr["type"])
+		}
+		if _, ok := addr["$ref"]; ok {
+			t.Errorf("%s: $ref should be stripped", name)
+		}
+		city, ok := addr["properties"].(map[string]any)["city"].(map[string]any)
+		if !ok || city["type"] != "STRING" {
+			t.Errorf("%s: city = %v, want STRING property", name, city)
+		}
+	}
+	if got := props["home"].(map[string]any)["description"]; got != "home address" {
+		t.Errorf("home: description = %v, want sibling description preserved", got)
+	}
+
+	// 递归引用在第二层以 OBJECT 兜底
+	next := props["chain"].(map[string]any)["properties"].(map[string]any)["next"].(map[string]any)
+	if next["type"] != "OBJECT" || len(next) != 1 {
+		t.Errorf("recursive ref: got %v, want {type: OBJECT}", next)
+	}
+}
+
+// TestCleanJSONSchema_Enum 测试 enum 与联合类型一起使用时的处理
+func TestCleanJSONSchema_Enum(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"mode":   map[string]any{"type": []any{"string", "null"}, "enum": []any{"fast", nil, 2.5, true}},
+			"empty":  map[string]any{"type": []any{"string", "null"}, "enum": []any{nil}},
+			"bad":    map[string]any{"type": "string", "enum": "fast"},
+			"number": map[string]any{"type": "integer", "enum": []any{1.0, 2.0}},
+		},
+	}
+
+	props := cleanJSONSchema(schema)["properties"].(map[string]any)
+
+	mode := props["mode"].(map[string]any)
+	if mode["type"] != "STRING" {
+		t.Fatalf("mode: type = %v, want STRING", mode["type"])
+	}
+	got, _ := json.Marshal(mode["enum"])
+	if string(got) != `["fast","2.5","true"]` {
+		t.Errorf("mode: enum = %s, want members coerced to strings", got)
+	}
+	for _, name := range []string{"empty", "bad"} {
+		if _, ok := props[name].(map[string]any)["enum"]; ok {
+			t.Errorf("%s: enum should be dropped", name)
+		}
+	}
+	if got, _ := json.Marshal(props["number"].(map[string]any)["enum"]); string(got) != `[1,2]` {
+		t.Errorf("number: enum = %s, want non-STRING enum kept as-is", got)
+	}
+}
+
+func jsonEqual(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+// TestCleanJSONSchema_ArrayItemsDefault 测试缺失 items 的数组默认补齐 STRING items
+func TestCleanJSONSchema_ArrayItemsDefault(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"tags": map[string]any{"type": "array"},
+			"matrix": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "array"},
+			},
+			"tuple": map[string]any{
+				"type":  "array",
+				"items": []any{map[string]any{"type": "integer"}, map[string]any{"type": "string"}},
+			},
+			"nested": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"ids": map[string]any{"type": []any{"array", "null"}},
+				},
+			},
+		},
+	}
+
+	props := cleanJSONSchema(schema)["properties"].(map[string]any)
+	itemsOf := func(v any) map[string]any {
+		items, ok := v.(map[string]any)["items"].(map[string]any)
+		if !ok {
+			t.Fatalf("expected items map in %v", v)
+		}
+		return items
+	}
+
+	if got := itemsOf(props["tags"])["type"]; got != "STRING" {
+		t.Errorf("tags: items type = %v, want STRING", got)
+	}
+	if got := itemsOf(itemsOf(props["matrix"]))["type"]; got != "STRING" {
+		t.Errorf("matrix: inner items type = %v, want STRING", got)
+	}
+	if got := itemsOf(props["tuple"])["type"]; got != "INTEGER" {
+		t.Errorf("tuple: items type = %v, want first tuple schema", got)
+	}
+	ids := props["nested"].(map[string]any)["properties"].(map[string]any)["ids"]
+	if got := itemsOf(ids)["type"]; got != "STRING" {
+		t.Errorf("nested: items type = %v, want STRING", got)
+	}
+}
+
+// TestCleanJSONSchema_PropertyNamedLikeKeyword 测试名为 type 的属性不会被当作 schema 关键字删除
+func TestCleanJSONSchema_PropertyNamedLikeKeyword(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"type":    map[string]any{"type": "string", "description": "kind of record"},
+			"name":    map[string]any{"type": "string"},
+			"pattern": map[string]any{"type": "string", "minLength": 1.0},
+		},
+		"required": []any{"type", "name"},
+	}
+
+	result := cleanJSONSchema(schema)
+	props := result["properties"].(map[string]any)
+	typeProp, ok := props["type"].(map[string]any)
+	if !ok {
+		t.Fatalf("property named type was dropped: %v", props)
+	}
+	want := map[string]any{"type": "STRING", "description": "kind of record"}
+	if !jsonEqual(typeProp, want) {
+		t.Errorf("type property = %v, want %v", typeProp, want)
+	}
+	if !jsonEqual(props["pattern"], map[string]any{"type": "STRING"}) {
+		t.Errorf("pattern property = %v, want cleaned schema kept", props["pattern"])
+	}
+	if !jsonEqual(result["required"], []any{"type", "name"}) {
+		t.Errorf("required = %v, want [type name]", result["required"])
+	}
+}
+
+// TestCleanJSONSchema_PropertyNamedEnum 测试名为 enum 的属性不会被当作 enum 关键字清理
+func TestCleanJSONSchema_PropertyNamedEnum(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"enum": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"mode": map[string]any{"type": "string", "enum": []any{"a", "b"}},
+		},
+		"required": []any{"enum"},
+	}
+
+	result := cleanJSONSchema(schema)
+	props := result["properties"].(map[string]any)
+	want := map[string]any{"type": "ARRAY", "items": map[string]any{"type": "STRING"}}
+	if !jsonEqual(props["enum"], want) {
+		t.Fatalf("enum property = %v, want %v", props["enum"], want)
+	}
+	if !jsonEqual(props["mode"].(map[string]any)["enum"], []any{"a", "b"}) {
+		t.Errorf("mode enum = %v, want [a b]", props["mode"].(map[string]any)["enum"])
+	}
+	if !jsonEqual(result["required"], []any{"enum"}) {
+		t.Errorf("required = %v, want [enum]", result["required"])
+	}
+}
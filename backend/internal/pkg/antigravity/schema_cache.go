@@ -0,0 +1,66 @@
+package antigravity
+
+import (
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// schemaCacheMaxEntries 清理结果缓存的最大条目数，超出后整体清空重建
+const schemaCacheMaxEntries = 1024
+
+type schemaCacheEntry struct {
+	raw     string
+	cleaned map[string]any
+}
+
+// schemaCache 以 schema 原始 JSON 的校验和（xxhash）为键缓存 cleanJSONSchema 的结果。
+// 工具密集型客户端每次请求都会携带相同的 tools，缓存可避免重复递归清理。
+type schemaCache struct {
+	mu      sync.RWMutex
+	entries map[uint64]schemaCacheEntry
+}
+
+var defaultSchemaCache = &schemaCache{entries: make(map[uint64]schemaCacheEntry)}
+
+func (c *schemaCache) get(key uint64, raw []byte) (map[string]any, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+	// 校验原始内容，防止哈希碰撞返回错误结果
+	if !ok || entry.raw != string(raw) {
+		return nil, false
+	}
+	return entry.cleaned, true
+}
+
+func (c *schemaCache) set(key uint64, raw []byte, cleaned map[string]any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= schemaCacheMaxEntries {
+		c.entries = make(map[uint64]schemaCacheEntry)
+	}
+	c.entries[key] = schemaCacheEntry{raw: string(raw), cleaned: cleaned}
+}
+
+// cleanJSONSchemaCached 带缓存的 cleanJSONSchema。
+// raw 为解码前的 schema 原始 JSON（ClaudeTool.rawInputSchema），直接对其求哈希作为键，
+// 命中路径不再序列化 schema；没有原始字节（代码构造的请求）时不走缓存。
+// 返回的 map 在多个请求间共享，调用方只能读取（序列化），不得修改。
+func cleanJSONSchemaCached(schema map[string]any, raw []byte) map[string]any {
+	if schema == nil {
+		return nil
+	}
+	if len(raw) == 0 {
+		return cleanJSONSchema(schema)
+	}
+	key := xxhash.Sum64(raw)
+	if cleaned, ok := defaultSchemaCache.get(key, raw); ok {
+		return cleaned
+	}
+	cleaned := cleanJSONSchema(schema)
+	if cleaned != nil {
+		defaultSchemaCache.set(key, raw, cleaned)
+	}
+	return cleaned
+}
@@ -0,0 +1,155 @@
+package antigravity
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func benchmarkToolSchema(i int) map[string]any {
+	return map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type":    "object",
+		"properties": map[string]any{
+			"path":    map[string]any{"type": "string", "description": fmt.Sprintf("file path %d", i), "minLength": 1},
+			"limit":   map[string]any{"type": []any{"integer", "null"}, "minimum": 0, "maximum": 1000},
+			"pattern": map[string]any{"type": "string", "format": "regex"},
+			"options": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"recursive": map[string]any{"type": "boolean", "default": false},
+					"tags":      map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "uniqueItems": true},
+				},
+				"additionalProperties": map[string]any{"type": "string"},
+			},
+		},
+		"required": []any{"path", "missing"},
+	}
+}
+
+// decodeTestTool 通过 JSON 解码构造工具，与真实请求一样携带 input_schema 原始字节
+func decodeTestTool(t testing.TB, name string, schema map[string]any) ClaudeTool {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"name": name, "input_schema": schema})
+	if err != nil {
+		t.Fatalf("marshal tool: %v", err)
+	}
+	var tool ClaudeTool
+	if err := json.Unmarshal(body, &tool); err != nil {
+		t.Fatalf("unmarshal tool: %v", err)
+	}
+	return tool
+}
+
+func TestCleanJSONSchemaCached_MatchesUncached(t *testing.T) {
+	tool := decodeTestTool(t, "tool_1", benchmarkToolSchema(1))
+
+	want, err := json.Marshal(cleanJSONSchema(tool.InputSchema))
+	if err != nil {
+		t.Fatalf("marshal uncached: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		got, err := json.Marshal(cleanJSONSchemaCached(tool.InputSchema, tool.rawInputSchema))
+		if err != nil {
+			t.Fatalf("marshal cached: %v", err)
+		}
+		if string(got) != string(want) {
+			t.Fatalf("cached result mismatch (iteration %d):\n got: %s\nwant: %s", i, got, want)
+		}
+	}
+
+	// 内容不同的 schema 不能命中同一缓存项
+	other := decodeTestTool(t, "tool_2", benchmarkToolSchema(2))
+	otherJSON, _ := json.Marshal(cleanJSONSchemaCached(other.InputSchema, other.rawInputSchema))
+	if string(otherJSON) == string(want) {
+		t.Fatalf("different schema returned identical cleaned result")
+	}
+}
+
+func TestClaudeTool_UnmarshalKeepsRawInputSchema(t *testing.T) {
+	var tools []ClaudeTool
+	body := `[
+		{"name":"std","input_schema":{"type":"object","properties":{"a":{"type":"string"}}}},
+		{"type":"custom","name":"mcp","custom":{"description":"d","input_schema":{"type":"object"}}},
+		{"name":"none"}
+	]`
+	if err := json.Unmarshal([]byte(body), &tools); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if got := string(tools[0].rawInputSchema); got != `{"type":"object","properties":{"a":{"type":"string"}}}` {
+		t.Errorf("standard tool raw schema = %s", got)
+	}
+	if tools[0].InputSchema["type"] != "object" {
+		t.Errorf("standard tool schema not decoded: %v", tools[0].InputSchema)
+	}
+	if tools[1].Custom == nil || string(tools[1].Custom.rawInputSchema) != `{"type":"object"}` {
+		t.Errorf("custom tool raw schema not kept: %+v", tools[1].Custom)
+	}
+	if tools[1].Custom.Description != "d" {
+		t.Errorf("custom tool description = %q", tools[1].Custom.Description)
+	}
+	if tools[2].InputSchema != nil || tools[2].rawInputSchema != nil {
+		t.Errorf("missing input_schema should stay nil, got %v / %s", tools[2].InputSchema, tools[2].rawInputSchema)
+	}
+
+	// 非对象 schema 仍按原逻辑报错
+	var bad ClaudeTool
+	if err := json.Unmarshal([]byte(`{"name":"x","input_schema":"oops"}`), &bad); err == nil {
+		t.Fatalf("expected error for non-object input_schema")
+	}
+}
+
+func TestCleanJSONSchemaCached_WithoutRawSkipsCache(t *testing.T) {
+	schema := benchmarkToolSchema(3)
+	before := len(defaultSchemaCache.entries)
+	if got := cleanJSONSchemaCached(schema, nil); got == nil {
+		t.Fatalf("expected cleaned schema")
+	}
+	if after := len(defaultSchemaCache.entries); after != before {
+		t.Fatalf("schema without raw bytes should not be cached (%d -> %d)", before, after)
+	}
+}
+
+func TestSchemaCache_BoundedSize(t *testing.T) {
+	c := &schemaCache{entries: make(map[uint64]schemaCacheEntry)}
+	for i := 0; i < schemaCacheMaxEntries+10; i++ {
+		c.set(uint64(i), []byte(fmt.Sprint(i)), map[string]any{})
+	}
+	if len(c.entries) > schemaCacheMaxEntries {
+		t.Fatalf("cache exceeded max entries: %d", len(c.entries))
+	}
+	if _, ok := c.get(uint64(schemaCacheMaxEntries+9), []byte("0")); ok {
+		t.Fatalf("expected raw mismatch to miss")
+	}
+}
+
+func benchmarkClaudeTools(b *testing.B, n int) []ClaudeTool {
+	tools := make([]ClaudeTool, 0, n)
+	for i := 0; i < n; i++ {
+		tool := decodeTestTool(b, fmt.Sprintf("tool_%d", i), benchmarkToolSchema(i))
+		tool.Description = "benchmark tool"
+		tools = append(tools, tool)
+	}
+	return tools
+}
+
+// BenchmarkBuildTools_Uncached 每次请求都完整清理 tools schema（基线）。
+func BenchmarkBuildTools_Uncached(b *testing.B) {
+	tools := benchmarkClaudeTools(b, 32)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, tool := range tools {
+			_ = cleanJSONSchema(tool.InputSchema)
+		}
+	}
+}
+
+// BenchmarkBuildTools_Cached 相同 tools 重复请求时命中校验和缓存。
+func BenchmarkBuildTools_Cached(b *testing.B) {
+	tools := benchmarkClaudeTools(b, 32)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = buildTools(tools)
+	}
+}
@@ -0,0 +1,80 @@
+package antigravity
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// FuzzCleanJSONSchema 对任意（可能是恶意或畸形的）工具 schema 进行清理，
+// 断言不会 panic、结果始终包含 type/properties，且任意深度都不保留被排除的字段。
+func FuzzCleanJSONSchema(f *testing.F) {
+	seeds := []string{
+		`{}`,
+		`{"type":"object","properties":{"a":{"type":"string","minLength":1}}}`,
+		`{"type":["string","null"],"anyOf":[{"type":"null"},{"description":"x"}]}`,
+		`{"$schema":"x","$defs":{"A":{"type":"integer"}},"properties":{"a":{"$ref":"#/$defs/A"}},"required":["a","b"]}`,
+		`{"properties":{"list":{"type":"array","items":[{"type":"string","format":"uri"},{"enum":[1,2]}]}}}`,
+		`{"additionalProperties":{"type":"string"},"type":7,"properties":[]}`,
+		`{"required":"oops","properties":{"x":{"properties":{"y":{"properties":{"z":{"default":1}}}}}}}`,
+		`{"properties":{"default":{"type":"string"}}}`,
+		`{"properties":{"pattern":{}}}`,
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, raw string) {
+		var schema map[string]any
+		if err := json.Unmarshal([]byte(raw), &schema); err != nil || schema == nil {
+			return
+		}
+
+		cleaned := cleanJSONSchema(schema)
+		if cleaned == nil {
+			t.Fatalf("cleanJSONSchema returned nil for %s", raw)
+		}
+		if _, ok := cleaned["type"]; !ok {
+			t.Fatalf("cleaned schema missing type: %v", cleaned)
+		}
+		if _, ok := cleaned["properties"]; !ok {
+			t.Fatalf("cleaned schema missing properties: %v", cleaned)
+		}
+		if path, found := findExcludedSchemaKey(cleaned, "$"); found {
+			t.Fatalf("excluded key retained at %s: %v", path, cleaned)
+		}
+		if _, err := json.Marshal(cleaned); err != nil {
+			t.Fatalf("cleaned schema is not serializable: %v", err)
+		}
+	})
+}
+
+func findExcludedSchemaKey(value any, path string) (string, bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if excludedSchemaKeys[k] {
+				return path + "." + k, true
+			}
+			if props, ok := val.(map[string]any); ok && k == "properties" {
+				// properties 的键是属性名而非 schema 关键字，只检查各属性的 schema
+				for name, prop := range props {
+					if p, found := findExcludedSchemaKey(prop, path+".properties."+name); found {
+						return p, true
+					}
+				}
+				continue
+			}
+			if p, found := findExcludedSchemaKey(val, path+"."+k); found {
+				return p, true
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if p, found := findExcludedSchemaKey(item, fmt.Sprintf("%s[%d]", path, i)); found {
+				return p, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,123 @@
+package gemini
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrNoErrorObject 响应体中不包含 error 字段
+var ErrNoErrorObject = errors.New("gemini: response body has no error object")
+
+// GeminiError Google 风格错误响应中的 error 对象
+// 形如 {"error":{"code":429,"message":"...","status":"RESOURCE_EXHAUSTED"}}
+type GeminiError struct {
+	Code    int    `json:"code"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	// Details google.rpc 错误详情（如 RetryInfo、QuotaFailure），按 @type 区分
+	Details []map[string]any `json:"details,omitempty"`
+}
+
+// ParseError 解析 Gemini 错误响应体，返回数值 code、机器可读的 status 与错误信息。
+// 兼容 error 为纯字符串，以及流式接口返回的数组包裹形式（[{"error":{...}}]）。
+func ParseError(body []byte) (*GeminiError, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil, ErrNoErrorObject
+	}
+	if body[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			return nil, ErrNoErrorObject
+		}
+		body = items[0]
+	}
+
+	var envelope struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, err
+	}
+	raw := bytes.TrimSpace(envelope.Error)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, ErrNoErrorObject
+	}
+
+	if raw[0] == '"' {
+		var msg string
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return nil, err
+		}
+		return &GeminiError{Message: msg}, nil
+	}
+
+	var parsed GeminiError
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, err
+	}
+	parsed.Status = strings.ToUpper(strings.TrimSpace(parsed.Status))
+	return &parsed, nil
+}
+
+// ParseErrorMessage 返回 Gemini 错误响应中的错误信息，无法解析时返回空字符串
+func ParseErrorMessage(body []byte) string {
+	parsed, err := ParseError(body)
+	if err != nil {
+		return ""
+	}
+	return parsed.Message
+}
+
+// IsRetryable 判断 Gemini 错误是否可重试。
+// 优先依据响应体中的 status：UNAVAILABLE/RESOURCE_EXHAUSTED 可重试，INVALID_ARGUMENT/PERMISSION_DENIED 不可重试；
+// 其余情况按 HTTP 状态码判断（408/429/500/502/503/504 可重试）。
+func IsRetryable(body []byte, statusCode int) bool {
+	if parsed, err := ParseError(body); err == nil {
+		switch parsed.Status {
+		case "UNAVAILABLE", "RESOURCE_EXHAUSTED":
+			return true
+		case "INVALID_ARGUMENT", "PERMISSION_DENIED":
+			return false
+		}
+	}
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseRetryDelay 从 error.details 中 @type 以 RetryInfo 结尾的条目解析 retryDelay（如 "17s"）。
+// 未找到或无法解析时返回 false，由调用方使用自身的退避策略。
+func ParseRetryDelay(body []byte) (time.Duration, bool) {
+	parsed, err := ParseError(body)
+	if err != nil {
+		return 0, false
+	}
+	for _, detail := range parsed.Details {
+		typ, _ := detail["@type"].(string)
+		if !strings.HasSuffix(typ, "RetryInfo") {
+			continue
+		}
+		raw, ok := detail["retryDelay"].(string)
+		if !ok {
+			continue
+		}
+		delay, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil || delay < 0 {
+			continue
+		}
+		return delay, true
+	}
+	return 0, false
+}
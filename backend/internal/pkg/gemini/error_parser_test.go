@@ -0,0 +1,101 @@
+package gemini
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want *GeminiError
+	}{
+		{
+			name: "object",
+			body: `{"error":{"code":429,"message":"Resource has been exhausted","status":"RESOURCE_EXHAUSTED"}}`,
+			want: &GeminiError{Code: 429, Status: "RESOURCE_EXHAUSTED", Message: "Resource has been exhausted"},
+		},
+		{
+			name: "bare string",
+			body: `{"error":"permission denied"}`,
+			want: &GeminiError{Message: "permission denied"},
+		},
+		{
+			name: "array wrapped",
+			body: `[{"error":{"code":403,"message":"denied","status":"permission_denied"}}]`,
+			want: &GeminiError{Code: 403, Status: "PERMISSION_DENIED", Message: "denied"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseError([]byte(tt.body))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseError_NoErrorObject(t *testing.T) {
+	for _, body := range []string{``, `{}`, `{"error":null}`, `[]`} {
+		_, err := ParseError([]byte(body))
+		require.True(t, errors.Is(err, ErrNoErrorObject), "body=%q err=%v", body, err)
+	}
+	_, err := ParseError([]byte(`not json`))
+	require.Error(t, err)
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	require.Equal(t, "quota exceeded", ParseErrorMessage([]byte(`{"error":{"code":429,"message":"quota exceeded"}}`)))
+	require.Equal(t, "boom", ParseErrorMessage([]byte(`{"error":"boom"}`)))
+	require.Equal(t, "", ParseErrorMessage([]byte(`not json`)))
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		want   bool
+	}{
+		{name: "resource exhausted", body: `{"error":{"code":429,"status":"RESOURCE_EXHAUSTED"}}`, status: 429, want: true},
+		{name: "unavailable status on 500", body: `{"error":{"code":500,"status":"UNAVAILABLE"}}`, status: 500, want: true},
+		{name: "invalid argument", body: `{"error":{"code":400,"status":"INVALID_ARGUMENT"}}`, status: 400, want: false},
+		{name: "permission denied overrides 503", body: `{"error":{"code":403,"status":"PERMISSION_DENIED"}}`, status: 503, want: false},
+		{name: "unknown status falls back to http", body: `{"error":{"code":500,"status":"INTERNAL"}}`, status: 500, want: true},
+		{name: "plain 502", body: `bad gateway`, status: 502, want: true},
+		{name: "plain 408", body: ``, status: 408, want: true},
+		{name: "plain 404", body: ``, status: 404, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsRetryable([]byte(tt.body), tt.status))
+		})
+	}
+}
+
+func TestParseRetryDelay(t *testing.T) {
+	body := `{"error":{"code":429,"status":"RESOURCE_EXHAUSTED","details":[
+		{"@type":"type.googleapis.com/google.rpc.QuotaFailure","violations":[]},
+		{"@type":"type.googleapis.com/google.rpc.RetryInfo","retryDelay":"17s"}]}}`
+	delay, ok := ParseRetryDelay([]byte(body))
+	require.True(t, ok)
+	require.Equal(t, 17*time.Second, delay)
+
+	delay, ok = ParseRetryDelay([]byte(`{"error":{"details":[{"@type":"type.googleapis.com/google.rpc.RetryInfo","retryDelay":"1.5s"}]}}`))
+	require.True(t, ok)
+	require.Equal(t, 1500*time.Millisecond, delay)
+
+	for _, body := range []string{
+		`{"error":{"code":429,"status":"RESOURCE_EXHAUSTED"}}`,
+		`{"error":{"details":[{"@type":"type.googleapis.com/google.rpc.RetryInfo","retryDelay":"soon"}]}}`,
+		`{"error":"rate limited"}`,
+		`not json`,
+	} {
+		_, ok := ParseRetryDelay([]byte(body))
+		require.False(t, ok, "body=%s", body)
+	}
+}
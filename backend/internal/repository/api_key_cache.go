@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/service"
@@ -15,6 +16,45 @@ const (
 	apiKeyRateLimitKeyPrefix = "apikey:ratelimit:"
 	apiKeyRateLimitDuration  = 24 * time.Hour
 	apiKeyAuthCachePrefix    = "apikey:auth:"
+
+	// 滑动窗口限流使用独立的有序集合，与固定窗口计数器互不影响
+	apiKeyRateLimitWindowKeyPrefix = "apikey:ratelimit:window:"
+)
+
+var (
+	// checkAndIncrementCreateScript 滑动窗口检查并记录创建尝试
+	// 使用 Redis TIME 命令获取服务器时间，避免多实例时钟不同步
+	// KEYS[1] = apikey:ratelimit:window:{userID}
+	// ARGV[1] = limit
+	// ARGV[2] = 窗口长度（毫秒）
+	// ARGV[3] = 成员后缀（保证同一毫秒内多次尝试不冲突）
+	// 返回: {allowed(1/0), 窗口内尝试次数, 最早一次尝试的时间戳（毫秒，无则为 0）, 当前时间戳（毫秒）}
+	checkAndIncrementCreateScript = redis.NewScript(`
+		local key = KEYS[1]
+		local limit = tonumber(ARGV[1])
+		local window = tonumber(ARGV[2])
+
+		local timeResult = redis.call('TIME')
+		local now = tonumber(timeResult[1]) * 1000 + math.floor(tonumber(timeResult[2]) / 1000)
+
+		redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+
+		local allowed = 0
+		local count = redis.call('ZCARD', key)
+		if count < limit then
+			redis.call('ZADD', key, now, timeResult[1] .. timeResult[2] .. '-' .. ARGV[3])
+			redis.call('PEXPIRE', key, window)
+			count = count + 1
+			allowed = 1
+		end
+
+		local oldest = 0
+		local first = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+		if first[2] then
+			oldest = tonumber(first[2])
+		end
+		return {allowed, count, oldest, now}
+	`)
 )
 
 // apiKeyRateLimitKey generates the Redis key for API key creation rate limiting.
@@ -22,15 +62,19 @@ func apiKeyRateLimitKey(userID int64) string {
 	return fmt.Sprintf("%s%d", apiKeyRateLimitKeyPrefix, userID)
 }
 
+func apiKeyRateLimitWindowKey(userID int64) string {
+	return fmt.Sprintf("%s%d", apiKeyRateLimitWindowKeyPrefix, userID)
+}
+
 func apiKeyAuthCacheKey(key string) string {
 	return fmt.Sprintf("%s%s", apiKeyAuthCachePrefix, key)
 }
 
 type apiKeyCache struct {
-	rdb *redis.Client
+	rdb redis.UniversalClient
 }
 
-func NewAPIKeyCache(rdb *redis.Client) service.APIKeyCache {
+func NewAPIKeyCache(rdb redis.UniversalClient) service.APIKeyCache {
 	return &apiKeyCache{rdb: rdb}
 }
 
@@ -40,7 +84,10 @@ func (c *apiKeyCache) GetCreateAttemptCount(ctx context.Context, userID int64) (
 	if errors.Is(err, redis.Nil) {
 		return 0, nil
 	}
-	return count, err
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
 }
 
 func (c *apiKeyCache) IncrementCreateAttemptCount(ctx context.Context, userID int64) error {
@@ -53,8 +100,46 @@ func (c *apiKeyCache) IncrementCreateAttemptCount(ctx context.Context, userID in
 }
 
 func (c *apiKeyCache) DeleteCreateAttemptCount(ctx context.Context, userID int64) error {
-	key := apiKeyRateLimitKey(userID)
-	return c.rdb.Del(ctx, key).Err()
+	// 两个键不在同一 slot，分别删除以兼容 Redis Cluster
+	pipe := c.rdb.Pipeline()
+	pipe.Del(ctx, apiKeyRateLimitKey(userID))
+	pipe.Del(ctx, apiKeyRateLimitWindowKey(userID))
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// CheckAndIncrementCreate 使用有序集合记录 24h 内的创建尝试时间戳，
+// 与固定窗口不同，额度只会随最早一次尝试滑出窗口逐个恢复，无法在窗口切换时集中突发。
+func (c *apiKeyCache) CheckAndIncrementCreate(ctx context.Context, userID int64, limit int) (bool, int, time.Time, error) {
+	if limit <= 0 {
+		return false, 0, time.Time{}, nil
+	}
+	result, err := checkAndIncrementCreateScript.Run(
+		ctx,
+		c.rdb,
+		[]string{apiKeyRateLimitWindowKey(userID)},
+		limit,
+		apiKeyRateLimitDuration.Milliseconds(),
+		strconv.FormatInt(time.Now().UnixNano(), 10),
+	).Int64Slice()
+	if err != nil {
+		return false, 0, time.Time{}, err
+	}
+	if len(result) != 4 {
+		return false, 0, time.Time{}, fmt.Errorf("unexpected rate limit script result: %v", result)
+	}
+
+	allowed := result[0] == 1
+	remaining := limit - int(result[1])
+	if remaining < 0 {
+		remaining = 0
+	}
+	oldest := result[2]
+	if oldest == 0 {
+		oldest = result[3]
+	}
+	resetAt := time.UnixMilli(oldest).Add(apiKeyRateLimitDuration)
+	return allowed, remaining, resetAt, nil
 }
 
 func (c *apiKeyCache) IncrementDailyUsage(ctx context.Context, apiKey string) error {
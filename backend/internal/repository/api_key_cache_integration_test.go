@@ -78,6 +78,58 @@ func (s *ApiKeyCacheSuite) TestCreateAttemptCount() {
 	}
 }
 
+func (s *ApiKeyCacheSuite) TestCheckAndIncrementCreate() {
+	rdb := testRedis(s.T())
+	cache := &apiKeyCache{rdb: rdb}
+	ctx := context.Background()
+	userID := int64(7)
+
+	before := time.Now()
+	allowed, remaining, resetAt, err := cache.CheckAndIncrementCreate(ctx, userID, 2)
+	require.NoError(s.T(), err, "CheckAndIncrementCreate")
+	require.True(s.T(), allowed)
+	require.Equal(s.T(), 1, remaining)
+	require.WithinDuration(s.T(), before.Add(apiKeyRateLimitDuration), resetAt, 5*time.Second)
+
+	allowed, remaining, _, err = cache.CheckAndIncrementCreate(ctx, userID, 2)
+	require.NoError(s.T(), err)
+	require.True(s.T(), allowed)
+	require.Zero(s.T(), remaining)
+
+	// 超限后不再记录新的尝试
+	allowed, remaining, _, err = cache.CheckAndIncrementCreate(ctx, userID, 2)
+	require.NoError(s.T(), err)
+	require.False(s.T(), allowed)
+	require.Zero(s.T(), remaining)
+
+	key := apiKeyRateLimitWindowKey(userID)
+	count, err := rdb.ZCard(ctx, key).Result()
+	require.NoError(s.T(), err, "ZCard")
+	require.Equal(s.T(), int64(2), count)
+
+	ttl, err := rdb.TTL(ctx, key).Result()
+	require.NoError(s.T(), err, "TTL")
+	s.AssertTTLWithin(ttl, 1*time.Second, apiKeyRateLimitDuration)
+
+	// 将最早一次尝试移出窗口后恢复一个额度
+	oldest, err := rdb.ZRangeWithScores(ctx, key, 0, 0).Result()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), oldest, 1)
+	expired := float64(time.Now().Add(-apiKeyRateLimitDuration - time.Minute).UnixMilli())
+	require.NoError(s.T(), rdb.ZAdd(ctx, key, redis.Z{Score: expired, Member: oldest[0].Member}).Err())
+
+	allowed, remaining, _, err = cache.CheckAndIncrementCreate(ctx, userID, 2)
+	require.NoError(s.T(), err)
+	require.True(s.T(), allowed)
+	require.Zero(s.T(), remaining)
+
+	// DeleteCreateAttemptCount 同时清除滑动窗口
+	require.NoError(s.T(), cache.DeleteCreateAttemptCount(ctx, userID))
+	exists, err := rdb.Exists(ctx, key).Result()
+	require.NoError(s.T(), err)
+	require.Zero(s.T(), exists)
+}
+
 func (s *ApiKeyCacheSuite) TestDailyUsage() {
 	tests := []struct {
 		name string
@@ -3,9 +3,12 @@
 package repository
 
 import (
+	"context"
 	"math"
 	"testing"
+	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/require"
 )
 
@@ -44,3 +47,39 @@ func TestApiKeyRateLimitKey(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCreateAttemptCount_ErrorReturnsZero(t *testing.T) {
+	// 指向不可达地址，模拟非 redis.Nil 的真实错误
+	rdb := redis.NewClient(&redis.Options{
+		Addr:        "127.0.0.1:1",
+		DialTimeout: 100 * time.Millisecond,
+		MaxRetries:  -1,
+	})
+	defer func() { _ = rdb.Close() }()
+
+	cache := NewAPIKeyCache(rdb)
+	count, err := cache.GetCreateAttemptCount(context.Background(), 1)
+	require.Error(t, err)
+	require.Zero(t, count)
+}
+
+func TestApiKeyRateLimitWindowKey(t *testing.T) {
+	require.Equal(t, "apikey:ratelimit:window:123", apiKeyRateLimitWindowKey(123))
+}
+
+func TestCheckAndIncrementCreate_NonPositiveLimitSkipsRedis(t *testing.T) {
+	// 不可达地址：若访问 Redis 会返回错误
+	rdb := redis.NewClient(&redis.Options{
+		Addr:        "127.0.0.1:1",
+		DialTimeout: 100 * time.Millisecond,
+		MaxRetries:  -1,
+	})
+	defer func() { _ = rdb.Close() }()
+
+	cache := NewAPIKeyCache(rdb)
+	allowed, remaining, resetAt, err := cache.CheckAndIncrementCreate(context.Background(), 1, 0)
+	require.NoError(t, err)
+	require.False(t, allowed)
+	require.Zero(t, remaining)
+	require.True(t, resetAt.IsZero())
+}
@@ -2,9 +2,14 @@ package repository
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/service"
 	"github.com/redis/go-redis/v9"
@@ -27,18 +32,26 @@ const (
 	accountSlotKeyPrefix = "concurrency:account:"
 	// 格式: concurrency:user:{userID}
 	userSlotKeyPrefix = "concurrency:user:"
+	// 格式: concurrency:group:{groupID}
+	groupSlotKeyPrefix = "concurrency:group:"
 	// 等待队列计数器格式: concurrency:wait:{userID}
 	waitQueueKeyPrefix = "concurrency:wait:"
 	// 账号级等待队列计数器格式: wait:account:{accountID}
 	accountWaitKeyPrefix = "wait:account:"
+	// 滑动窗口限流键格式: ratelimit:{key}（有序集合，成员为请求标识，分数为毫秒时间戳）
+	rateLimitKeyPrefix = "ratelimit:"
 
 	// 默认槽位过期时间（分钟），可通过配置覆盖
 	defaultSlotTTLMinutes = 15
+
+	// scanAccountSlotsBatch SCAN 每批返回的键数量上限
+	scanAccountSlotsBatch = 200
 )
 
 var (
 	// acquireScript 使用有序集合计数并在未达上限时添加槽位
 	// 使用 Redis TIME 命令获取服务器时间，避免多实例时钟不同步问题
+	// 成功时返回占用后的槽位数（>= 1），达到上限时返回 0
 	// KEYS[1] = 有序集合键 (concurrency:account:{id} / concurrency:user:{id})
 	// ARGV[1] = maxConcurrency
 	// ARGV[2] = TTL（秒）
@@ -62,7 +75,7 @@ var (
 		if exists ~= false then
 			redis.call('ZADD', key, now, requestID)
 			redis.call('EXPIRE', key, ttl)
-			return 1
+			return redis.call('ZCARD', key)
 		end
 
 		-- 检查是否达到并发上限
@@ -70,12 +83,36 @@ var (
 		if count < maxConcurrency then
 			redis.call('ZADD', key, now, requestID)
 			redis.call('EXPIRE', key, ttl)
-			return 1
+			return count + 1
 		end
 
 		return 0
 	`)
 
+	// renewSlotScript 为仍然存活的槽位续期（刷新时间戳），用于长时间流式请求
+	// 槽位已过期/已释放时返回 0，不会重新占用
+	// KEYS[1] = 有序集合键
+	// ARGV[1] = TTL（秒）
+	// ARGV[2] = requestID
+	renewSlotScript = redis.NewScript(`
+		local key = KEYS[1]
+		local ttl = tonumber(ARGV[1])
+		local requestID = ARGV[2]
+
+		local timeResult = redis.call('TIME')
+		local now = tonumber(timeResult[1])
+
+		-- 先清理过期槽位，已过期的槽位视为已被驱逐
+		redis.call('ZREMRANGEBYSCORE', key, '-inf', now - ttl)
+		if redis.call('ZSCORE', key, requestID) == false then
+			return 0
+		end
+
+		redis.call('ZADD', key, 'XX', now, requestID)
+		redis.call('EXPIRE', key, ttl)
+		return 1
+	`)
+
 	// getCountScript 统计有序集合中的槽位数量并清理过期条目
 	// 使用 Redis TIME 命令获取服务器时间
 	// KEYS[1] = 有序集合键
@@ -194,6 +231,63 @@ var (
 			return result
 		`)
 
+	// getAccountLoadScript - single-account load query used in Redis Cluster mode
+	// KEYS[1] = concurrency:account:{accountID}
+	// KEYS[2] = wait:account:{accountID}（与 KEYS[1] 共享 hash tag，位于同一 slot）
+	// ARGV[1] = slot TTL (seconds)
+	getAccountLoadScript = redis.NewScript(`
+			local slotTTL = tonumber(ARGV[1])
+			local timeResult = redis.call('TIME')
+			local cutoffTime = tonumber(timeResult[1]) - slotTTL
+
+			redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', cutoffTime)
+			local currentConcurrency = redis.call('ZCARD', KEYS[1])
+
+			local waitingCount = redis.call('GET', KEYS[2])
+			if waitingCount == false then
+				waitingCount = 0
+			else
+				waitingCount = tonumber(waitingCount)
+			end
+
+			return {currentConcurrency, waitingCount}
+		`)
+
+	// allowRateScript - 滑动窗口限流：窗口内请求数未达上限时记录本次请求
+	// 使用 Redis TIME 命令获取服务器时间（毫秒精度）
+	// KEYS[1] = ratelimit:{key}
+	// ARGV[1] = limit
+	// ARGV[2] = 窗口长度（毫秒）
+	// ARGV[3] = 本次请求的唯一成员
+	// 返回 {allowed(1/0), retryAfterMs}
+	allowRateScript = redis.NewScript(`
+			local key = KEYS[1]
+			local limit = tonumber(ARGV[1])
+			local windowMs = tonumber(ARGV[2])
+
+			local timeResult = redis.call('TIME')
+			local nowMs = tonumber(timeResult[1]) * 1000 + math.floor(tonumber(timeResult[2]) / 1000)
+
+			redis.call('ZREMRANGEBYSCORE', key, '-inf', nowMs - windowMs)
+			local count = redis.call('ZCARD', key)
+			if count < limit then
+				redis.call('ZADD', key, nowMs, ARGV[3])
+				redis.call('PEXPIRE', key, windowMs)
+				return {1, 0}
+			end
+
+			-- 最早的一条记录滑出窗口后才会空出名额
+			local retryAfter = windowMs
+			local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+			if oldest[2] then
+				retryAfter = tonumber(oldest[2]) + windowMs - nowMs
+			end
+			if retryAfter < 0 then
+				retryAfter = 0
+			end
+			return {0, retryAfter}
+		`)
+
 	// cleanupExpiredSlotsScript - remove expired slots
 	// KEYS[1] = concurrency:account:{accountID}
 	// ARGV[1] = TTL (seconds)
@@ -208,15 +302,16 @@ var (
 )
 
 type concurrencyCache struct {
-	rdb                 *redis.Client
-	slotTTLSeconds      int // 槽位过期时间（秒）
-	waitQueueTTLSeconds int // 等待队列过期时间（秒）
+	rdb                 redis.UniversalClient
+	slotTTLSeconds      int  // 槽位过期时间（秒）
+	waitQueueTTLSeconds int  // 等待队列过期时间（秒）
+	clusterMode         bool // Redis Cluster 模式：账号键使用 hash tag，批量查询改为逐账号流水线
 }
 
 // NewConcurrencyCache 创建并发控制缓存
 // slotTTLMinutes: 槽位过期时间（分钟），0 或负数使用默认值 15 分钟
 // waitQueueTTLSeconds: 等待队列过期时间（秒），0 或负数使用 slot TTL
-func NewConcurrencyCache(rdb *redis.Client, slotTTLMinutes int, waitQueueTTLSeconds int) service.ConcurrencyCache {
+func NewConcurrencyCache(rdb redis.UniversalClient, slotTTLMinutes int, waitQueueTTLSeconds int) service.ConcurrencyCache {
 	if slotTTLMinutes <= 0 {
 		slotTTLMinutes = defaultSlotTTLMinutes
 	}
@@ -230,15 +325,56 @@ func NewConcurrencyCache(rdb *redis.Client, slotTTLMinutes int, waitQueueTTLSeco
 	}
 }
 
+// NewClusterConcurrencyCache 创建 Redis Cluster 兼容的并发控制缓存
+// 账号槽位键与账号等待键使用相同的 hash tag（{accountID}），保证 getAccountLoadScript 访问的键位于同一 slot。
+func NewClusterConcurrencyCache(rdb redis.UniversalClient, slotTTLMinutes int, waitQueueTTLSeconds int) service.ConcurrencyCache {
+	cache := NewConcurrencyCache(rdb, slotTTLMinutes, waitQueueTTLSeconds).(*concurrencyCache)
+	cache.clusterMode = true
+	return cache
+}
+
 // Helper functions for key generation
 func accountSlotKey(accountID int64) string {
 	return fmt.Sprintf("%s%d", accountSlotKeyPrefix, accountID)
 }
 
+// clusterAccountSlotKey 集群模式账号槽位键，格式: concurrency:account:{accountID}（花括号为 hash tag）
+func clusterAccountSlotKey(accountID int64) string {
+	return fmt.Sprintf("%s{%d}", accountSlotKeyPrefix, accountID)
+}
+
+// clusterAccountWaitKey 集群模式账号等待键，格式: wait:account:{accountID}（花括号为 hash tag）
+func clusterAccountWaitKey(accountID int64) string {
+	return fmt.Sprintf("%s{%d}", accountWaitKeyPrefix, accountID)
+}
+
+func (c *concurrencyCache) accountSlotKey(accountID int64) string {
+	if c.clusterMode {
+		return clusterAccountSlotKey(accountID)
+	}
+	return accountSlotKey(accountID)
+}
+
+func (c *concurrencyCache) accountWaitKey(accountID int64) string {
+	if c.clusterMode {
+		return clusterAccountWaitKey(accountID)
+	}
+	return accountWaitKey(accountID)
+}
+
+// accountLoadKeys getAccountLoadScript 访问的键（槽位键、等待键），集群模式下必须位于同一 slot
+func (c *concurrencyCache) accountLoadKeys(accountID int64) []string {
+	return []string{c.accountSlotKey(accountID), c.accountWaitKey(accountID)}
+}
+
 func userSlotKey(userID int64) string {
 	return fmt.Sprintf("%s%d", userSlotKeyPrefix, userID)
 }
 
+func groupSlotKey(groupID int64) string {
+	return fmt.Sprintf("%s%d", groupSlotKeyPrefix, groupID)
+}
+
 func waitQueueKey(userID int64) string {
 	return fmt.Sprintf("%s%d", waitQueueKeyPrefix, userID)
 }
@@ -249,23 +385,43 @@ func accountWaitKey(accountID int64) string {
 
 // Account slot operations
 
-func (c *concurrencyCache) AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error) {
-	key := accountSlotKey(accountID)
+// AcquireAccountSlot 成功时同时返回占用后的槽位数，供软上限预警使用，避免额外查询
+func (c *concurrencyCache) AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, int, error) {
+	key := c.accountSlotKey(accountID)
 	// 时间戳在 Lua 脚本内使用 Redis TIME 命令获取，确保多实例时钟一致
 	result, err := acquireScript.Run(ctx, c.rdb, []string{key}, maxConcurrency, c.slotTTLSeconds, requestID).Int()
 	if err != nil {
-		return false, err
+		return false, 0, err
 	}
-	return result == 1, nil
+	return result > 0, result, nil
 }
 
 func (c *concurrencyCache) ReleaseAccountSlot(ctx context.Context, accountID int64, requestID string) error {
-	key := accountSlotKey(accountID)
+	key := c.accountSlotKey(accountID)
 	return c.rdb.ZRem(ctx, key, requestID).Err()
 }
 
+// ReleaseAccountSlots 通过流水线批量 ZREM，单次网络往返释放多个账号槽位
+// 流水线中的每条命令都会执行，返回遇到的第一个错误
+func (c *concurrencyCache) ReleaseAccountSlots(ctx context.Context, releases []service.SlotRelease) error {
+	if len(releases) == 0 {
+		return nil
+	}
+	pipe := c.rdb.Pipeline()
+	for _, r := range releases {
+		pipe.ZRem(ctx, c.accountSlotKey(r.AccountID), r.RequestID)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RenewAccountSlot 为账号槽位续期；槽位已被驱逐时返回 service.ErrConcurrencySlotExpired
+func (c *concurrencyCache) RenewAccountSlot(ctx context.Context, accountID int64, requestID string) error {
+	return c.renewSlot(ctx, c.accountSlotKey(accountID), requestID)
+}
+
 func (c *concurrencyCache) GetAccountConcurrency(ctx context.Context, accountID int64) (int, error) {
-	key := accountSlotKey(accountID)
+	key := c.accountSlotKey(accountID)
 	// 时间戳在 Lua 脚本内使用 Redis TIME 命令获取
 	result, err := getCountScript.Run(ctx, c.rdb, []string{key}, c.slotTTLSeconds).Int()
 	if err != nil {
@@ -283,7 +439,7 @@ func (c *concurrencyCache) AcquireUserSlot(ctx context.Context, userID int64, ma
 	if err != nil {
 		return false, err
 	}
-	return result == 1, nil
+	return result > 0, nil
 }
 
 func (c *concurrencyCache) ReleaseUserSlot(ctx context.Context, userID int64, requestID string) error {
@@ -291,6 +447,22 @@ func (c *concurrencyCache) ReleaseUserSlot(ctx context.Context, userID int64, re
 	return c.rdb.ZRem(ctx, key, requestID).Err()
 }
 
+// RenewUserSlot 为用户槽位续期；槽位已被驱逐时返回 service.ErrConcurrencySlotExpired
+func (c *concurrencyCache) RenewUserSlot(ctx context.Context, userID int64, requestID string) error {
+	return c.renewSlot(ctx, userSlotKey(userID), requestID)
+}
+
+func (c *concurrencyCache) renewSlot(ctx context.Context, key, requestID string) error {
+	result, err := renewSlotScript.Run(ctx, c.rdb, []string{key}, c.slotTTLSeconds, requestID).Int()
+	if err != nil {
+		return err
+	}
+	if result != 1 {
+		return service.ErrConcurrencySlotExpired
+	}
+	return nil
+}
+
 func (c *concurrencyCache) GetUserConcurrency(ctx context.Context, userID int64) (int, error) {
 	key := userSlotKey(userID)
 	// 时间戳在 Lua 脚本内使用 Redis TIME 命令获取
@@ -301,6 +473,37 @@ func (c *concurrencyCache) GetUserConcurrency(ctx context.Context, userID int64)
 	return result, nil
 }
 
+// Group slot operations
+
+func (c *concurrencyCache) AcquireGroupSlot(ctx context.Context, groupID int64, maxConcurrency int, requestID string) (bool, error) {
+	key := groupSlotKey(groupID)
+	// 时间戳在 Lua 脚本内使用 Redis TIME 命令获取，确保多实例时钟一致
+	result, err := acquireScript.Run(ctx, c.rdb, []string{key}, maxConcurrency, c.slotTTLSeconds, requestID).Int()
+	if err != nil {
+		return false, err
+	}
+	return result > 0, nil
+}
+
+func (c *concurrencyCache) ReleaseGroupSlot(ctx context.Context, groupID int64, requestID string) error {
+	key := groupSlotKey(groupID)
+	return c.rdb.ZRem(ctx, key, requestID).Err()
+}
+
+// RenewGroupSlot 为分组槽位续期；槽位已被驱逐时返回 service.ErrConcurrencySlotExpired
+func (c *concurrencyCache) RenewGroupSlot(ctx context.Context, groupID int64, requestID string) error {
+	return c.renewSlot(ctx, groupSlotKey(groupID), requestID)
+}
+
+func (c *concurrencyCache) GetGroupConcurrency(ctx context.Context, groupID int64) (int, error) {
+	key := groupSlotKey(groupID)
+	result, err := getCountScript.Run(ctx, c.rdb, []string{key}, c.slotTTLSeconds).Int()
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
 // Wait queue operations
 
 func (c *concurrencyCache) IncrementWaitCount(ctx context.Context, userID int64, maxWait int) (bool, error) {
@@ -318,10 +521,22 @@ func (c *concurrencyCache) DecrementWaitCount(ctx context.Context, userID int64)
 	return err
 }
 
+func (c *concurrencyCache) GetUserWaitCount(ctx context.Context, userID int64) (int, error) {
+	key := waitQueueKey(userID)
+	val, err := c.rdb.Get(ctx, key).Int()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return val, nil
+}
+
 // Account wait queue operations
 
 func (c *concurrencyCache) IncrementAccountWaitCount(ctx context.Context, accountID int64, maxWait int) (bool, error) {
-	key := accountWaitKey(accountID)
+	key := c.accountWaitKey(accountID)
 	result, err := incrementAccountWaitScript.Run(ctx, c.rdb, []string{key}, maxWait, c.waitQueueTTLSeconds).Int()
 	if err != nil {
 		return false, err
@@ -330,20 +545,20 @@ func (c *concurrencyCache) IncrementAccountWaitCount(ctx context.Context, accoun
 }
 
 func (c *concurrencyCache) DecrementAccountWaitCount(ctx context.Context, accountID int64) error {
-	key := accountWaitKey(accountID)
+	key := c.accountWaitKey(accountID)
 	_, err := decrementWaitScript.Run(ctx, c.rdb, []string{key}).Result()
 	return err
 }
 
 func (c *concurrencyCache) GetAccountWaitingCount(ctx context.Context, accountID int64) (int, error) {
-	key := accountWaitKey(accountID)
+	key := c.accountWaitKey(accountID)
 	val, err := c.rdb.Get(ctx, key).Int()
-	if err != nil && !errors.Is(err, redis.Nil) {
-		return 0, err
-	}
 	if errors.Is(err, redis.Nil) {
 		return 0, nil
 	}
+	if err != nil {
+		return 0, err
+	}
 	return val, nil
 }
 
@@ -352,6 +567,10 @@ func (c *concurrencyCache) GetAccountsLoadBatch(ctx context.Context, accounts []
 		return map[int64]*service.AccountLoadInfo{}, nil
 	}
 
+	if c.clusterMode {
+		return c.getAccountsLoadBatchCluster(ctx, accounts)
+	}
+
 	args := []any{c.slotTTLSeconds}
 	for _, acc := range accounts {
 		args = append(args, acc.ID, acc.MaxConcurrency)
@@ -384,8 +603,162 @@ func (c *concurrencyCache) GetAccountsLoadBatch(ctx context.Context, accounts []
 	return loadMap, nil
 }
 
+// getAccountsLoadBatchCluster 集群模式下的批量负载查询
+// 批量脚本在 Lua 内拼接跨 slot 的键，集群模式不可用；改为每个账号一次单 slot 脚本调用，
+// 通过流水线合并网络往返。
+func (c *concurrencyCache) getAccountsLoadBatchCluster(ctx context.Context, accounts []service.AccountWithConcurrency) (map[int64]*service.AccountLoadInfo, error) {
+	pipe := c.rdb.Pipeline()
+	cmds := make([]*redis.Cmd, len(accounts))
+	for i, acc := range accounts {
+		cmds[i] = getAccountLoadScript.Eval(ctx, pipe, c.accountLoadKeys(acc.ID), c.slotTTLSeconds)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	loadMap := make(map[int64]*service.AccountLoadInfo, len(accounts))
+	for i, acc := range accounts {
+		vals, err := cmds[i].Int64Slice()
+		if err != nil {
+			return nil, err
+		}
+		if len(vals) < 2 {
+			return nil, fmt.Errorf("unexpected account load result for account %d", acc.ID)
+		}
+		currentConcurrency := int(vals[0])
+		waitingCount := int(vals[1])
+		loadRate := 0
+		if acc.MaxConcurrency > 0 {
+			loadRate = (currentConcurrency + waitingCount) * 100 / acc.MaxConcurrency
+		}
+		loadMap[acc.ID] = &service.AccountLoadInfo{
+			AccountID:          acc.ID,
+			CurrentConcurrency: currentConcurrency,
+			WaitingCount:       waitingCount,
+			LoadRate:           loadRate,
+		}
+	}
+	return loadMap, nil
+}
+
+// GetAllAccountConcurrency 扫描所有账号槽位键，返回清理过期槽位后的并发数（仅包含非零账号）
+// 使用 SCAN 分批遍历，避免 KEYS 阻塞 Redis；每批键通过流水线执行 getCountScript。
+// SCAN 只遍历单个节点，集群模式下对每个主节点分别扫描后合并。
+func (c *concurrencyCache) GetAllAccountConcurrency(ctx context.Context) (map[int64]int, error) {
+	cluster, ok := c.rdb.(*redis.ClusterClient)
+	if !ok {
+		result := make(map[int64]int)
+		if err := c.scanAccountSlotKeys(ctx, c.rdb, result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	var mu sync.Mutex
+	result := make(map[int64]int)
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+		partial := make(map[int64]int)
+		if err := c.scanAccountSlotKeys(ctx, node, partial); err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for id, n := range partial {
+			result[id] += n
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// scanAccountSlotKeys 在单个节点上 SCAN 账号槽位键并累加到 out
+func (c *concurrencyCache) scanAccountSlotKeys(ctx context.Context, node redis.UniversalClient, out map[int64]int) error {
+	var cursor uint64
+	for {
+		keys, next, err := node.Scan(ctx, cursor, accountSlotKeyPrefix+"*", scanAccountSlotsBatch).Result()
+		if err != nil {
+			return err
+		}
+		if err := c.countAccountSlotKeys(ctx, keys, out); err != nil {
+			return err
+		}
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+func (c *concurrencyCache) countAccountSlotKeys(ctx context.Context, keys []string, out map[int64]int) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(keys))
+	pipe := c.rdb.Pipeline()
+	cmds := make([]*redis.Cmd, 0, len(keys))
+	for _, key := range keys {
+		// 集群模式键带 hash tag: concurrency:account:{id}
+		raw := strings.Trim(strings.TrimPrefix(key, accountSlotKeyPrefix), "{}")
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+		cmds = append(cmds, getCountScript.Eval(ctx, pipe, []string{key}, c.slotTTLSeconds))
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	for i, cmd := range cmds {
+		n, err := cmd.Int()
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			out[ids[i]] += n
+		}
+	}
+	return nil
+}
+
+// AllowRate 滑动窗口限流：windowSeconds 内最多允许 limit 次请求
+// 被拒绝时 retryAfter 为最早一条记录滑出窗口所需的时间
+func (c *concurrencyCache) AllowRate(ctx context.Context, key string, limit int, windowSeconds int) (bool, time.Duration, error) {
+	if limit <= 0 || windowSeconds <= 0 {
+		return false, 0, fmt.Errorf("invalid rate limit: limit=%d window=%ds", limit, windowSeconds)
+	}
+	member, err := randomRateLimitMember()
+	if err != nil {
+		return false, 0, err
+	}
+	windowMs := int64(windowSeconds) * 1000
+	vals, err := allowRateScript.Run(ctx, c.rdb, []string{rateLimitKeyPrefix + key}, limit, windowMs, member).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	if len(vals) < 2 {
+		return false, 0, fmt.Errorf("unexpected rate limit result: %v", vals)
+	}
+	return vals[0] == 1, time.Duration(vals[1]) * time.Millisecond, nil
+}
+
+// randomRateLimitMember 生成有序集合成员，避免同一毫秒内的请求互相覆盖
+func randomRateLimitMember() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 func (c *concurrencyCache) CleanupExpiredAccountSlots(ctx context.Context, accountID int64) error {
-	key := accountSlotKey(accountID)
+	key := c.accountSlotKey(accountID)
 	_, err := cleanupExpiredSlotsScript.Run(ctx, c.rdb, []string{key}, c.slotTTLSeconds).Result()
 	return err
 }
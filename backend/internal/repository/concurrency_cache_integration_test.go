@@ -34,15 +34,17 @@ func (s *ConcurrencyCacheSuite) TestAccountSlot_AcquireAndRelease() {
 	accountID := int64(10)
 	reqID1, reqID2, reqID3 := "req1", "req2", "req3"
 
-	ok, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 2, reqID1)
+	ok, current, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 2, reqID1)
 	require.NoError(s.T(), err, "AcquireAccountSlot 1")
 	require.True(s.T(), ok)
+	require.Equal(s.T(), 1, current, "post-acquire count 1")
 
-	ok, err = s.cache.AcquireAccountSlot(s.ctx, accountID, 2, reqID2)
+	ok, current, err = s.cache.AcquireAccountSlot(s.ctx, accountID, 2, reqID2)
 	require.NoError(s.T(), err, "AcquireAccountSlot 2")
 	require.True(s.T(), ok)
+	require.Equal(s.T(), 2, current, "post-acquire count 2")
 
-	ok, err = s.cache.AcquireAccountSlot(s.ctx, accountID, 2, reqID3)
+	ok, _, err = s.cache.AcquireAccountSlot(s.ctx, accountID, 2, reqID3)
 	require.NoError(s.T(), err, "AcquireAccountSlot 3")
 	require.False(s.T(), ok, "expected third acquire to fail")
 
@@ -62,7 +64,7 @@ func (s *ConcurrencyCacheSuite) TestAccountSlot_TTL() {
 	reqID := "req_ttl_test"
 	slotKey := fmt.Sprintf("%s%d", accountSlotKeyPrefix, accountID)
 
-	ok, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 5, reqID)
+	ok, _, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 5, reqID)
 	require.NoError(s.T(), err, "AcquireAccountSlot")
 	require.True(s.T(), ok)
 
@@ -75,12 +77,12 @@ func (s *ConcurrencyCacheSuite) TestAccountSlot_DuplicateReqID() {
 	accountID := int64(12)
 	reqID := "dup-req"
 
-	ok, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 2, reqID)
+	ok, _, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 2, reqID)
 	require.NoError(s.T(), err)
 	require.True(s.T(), ok)
 
 	// Acquiring with same reqID should be idempotent
-	ok, err = s.cache.AcquireAccountSlot(s.ctx, accountID, 2, reqID)
+	ok, _, err = s.cache.AcquireAccountSlot(s.ctx, accountID, 2, reqID)
 	require.NoError(s.T(), err)
 	require.True(s.T(), ok)
 
@@ -93,7 +95,7 @@ func (s *ConcurrencyCacheSuite) TestAccountSlot_ReleaseIdempotent() {
 	accountID := int64(13)
 	reqID := "release-test"
 
-	ok, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 1, reqID)
+	ok, _, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 1, reqID)
 	require.NoError(s.T(), err)
 	require.True(s.T(), ok)
 
@@ -112,7 +114,7 @@ func (s *ConcurrencyCacheSuite) TestAccountSlot_MaxZero() {
 	accountID := int64(14)
 	reqID := "max-zero-test"
 
-	ok, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 0, reqID)
+	ok, _, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 0, reqID)
 	require.NoError(s.T(), err)
 	require.False(s.T(), ok, "expected acquire to fail with max=0")
 }
@@ -156,6 +158,29 @@ func (s *ConcurrencyCacheSuite) TestUserSlot_TTL() {
 	s.AssertTTLWithin(ttl, 1*time.Second, testSlotTTL)
 }
 
+func (s *ConcurrencyCacheSuite) TestGroupSlot_AcquireAndRelease() {
+	groupID := int64(30)
+
+	ok, err := s.cache.AcquireGroupSlot(s.ctx, groupID, 2, "req1")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+	ok, err = s.cache.AcquireGroupSlot(s.ctx, groupID, 2, "req2")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+	ok, err = s.cache.AcquireGroupSlot(s.ctx, groupID, 2, "req3")
+	require.NoError(s.T(), err)
+	require.False(s.T(), ok, "expected third acquire to fail")
+
+	ttl, err := s.rdb.TTL(s.ctx, fmt.Sprintf("%s%d", groupSlotKeyPrefix, groupID)).Result()
+	require.NoError(s.T(), err)
+	s.AssertTTLWithin(ttl, 1*time.Second, testSlotTTL)
+
+	require.NoError(s.T(), s.cache.ReleaseGroupSlot(s.ctx, groupID, "req1"))
+	cur, err := s.cache.GetGroupConcurrency(s.ctx, groupID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, cur)
+}
+
 func (s *ConcurrencyCacheSuite) TestWaitQueue_IncrementAndDecrement() {
 	userID := int64(20)
 	waitKey := fmt.Sprintf("%s%d", waitQueueKeyPrefix, userID)
@@ -185,6 +210,25 @@ func (s *ConcurrencyCacheSuite) TestWaitQueue_IncrementAndDecrement() {
 	require.Equal(s.T(), 1, val, "expected wait count 1")
 }
 
+func (s *ConcurrencyCacheSuite) TestGetUserWaitCount() {
+	userID := int64(21)
+
+	cnt, err := s.cache.GetUserWaitCount(s.ctx, userID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 0, cnt, "missing key should report 0")
+
+	ok, err := s.cache.IncrementWaitCount(s.ctx, userID, 5)
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+	ok, err = s.cache.IncrementWaitCount(s.ctx, userID, 5)
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+
+	cnt, err = s.cache.GetUserWaitCount(s.ctx, userID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 2, cnt)
+}
+
 func (s *ConcurrencyCacheSuite) TestWaitQueue_DecrementNoNegative() {
 	userID := int64(300)
 	waitKey := fmt.Sprintf("%s%d", waitQueueKeyPrefix, userID)
@@ -282,10 +326,10 @@ func (s *ConcurrencyCacheSuite) TestGetAccountsLoadBatch() {
 	account3 := int64(102)
 
 	// Account 1: 2/3 slots used, 1 waiting
-	ok, err := s.cache.AcquireAccountSlot(s.ctx, account1, 3, "req1")
+	ok, _, err := s.cache.AcquireAccountSlot(s.ctx, account1, 3, "req1")
 	require.NoError(s.T(), err)
 	require.True(s.T(), ok)
-	ok, err = s.cache.AcquireAccountSlot(s.ctx, account1, 3, "req2")
+	ok, _, err = s.cache.AcquireAccountSlot(s.ctx, account1, 3, "req2")
 	require.NoError(s.T(), err)
 	require.True(s.T(), ok)
 	ok, err = s.cache.IncrementAccountWaitCount(s.ctx, account1, 5)
@@ -293,7 +337,7 @@ func (s *ConcurrencyCacheSuite) TestGetAccountsLoadBatch() {
 	require.True(s.T(), ok)
 
 	// Account 2: 1/2 slots used, 0 waiting
-	ok, err = s.cache.AcquireAccountSlot(s.ctx, account2, 2, "req3")
+	ok, _, err = s.cache.AcquireAccountSlot(s.ctx, account2, 2, "req3")
 	require.NoError(s.T(), err)
 	require.True(s.T(), ok)
 
@@ -347,13 +391,13 @@ func (s *ConcurrencyCacheSuite) TestCleanupExpiredAccountSlots() {
 	slotKey := fmt.Sprintf("%s%d", accountSlotKeyPrefix, accountID)
 
 	// Acquire 3 slots
-	ok, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 5, "req1")
+	ok, _, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 5, "req1")
 	require.NoError(s.T(), err)
 	require.True(s.T(), ok)
-	ok, err = s.cache.AcquireAccountSlot(s.ctx, accountID, 5, "req2")
+	ok, _, err = s.cache.AcquireAccountSlot(s.ctx, accountID, 5, "req2")
 	require.NoError(s.T(), err)
 	require.True(s.T(), ok)
-	ok, err = s.cache.AcquireAccountSlot(s.ctx, accountID, 5, "req3")
+	ok, _, err = s.cache.AcquireAccountSlot(s.ctx, accountID, 5, "req3")
 	require.NoError(s.T(), err)
 	require.True(s.T(), ok)
 
@@ -390,10 +434,10 @@ func (s *ConcurrencyCacheSuite) TestCleanupExpiredAccountSlots_NoExpired() {
 	accountID := int64(201)
 
 	// Acquire 2 fresh slots
-	ok, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 5, "req1")
+	ok, _, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 5, "req1")
 	require.NoError(s.T(), err)
 	require.True(s.T(), ok)
-	ok, err = s.cache.AcquireAccountSlot(s.ctx, accountID, 5, "req2")
+	ok, _, err = s.cache.AcquireAccountSlot(s.ctx, accountID, 5, "req2")
 	require.NoError(s.T(), err)
 	require.True(s.T(), ok)
 
@@ -407,6 +451,160 @@ func (s *ConcurrencyCacheSuite) TestCleanupExpiredAccountSlots_NoExpired() {
 	require.Equal(s.T(), 2, cur)
 }
 
+func (s *ConcurrencyCacheSuite) TestClusterMode_GetAccountsLoadBatch() {
+	cache := NewClusterConcurrencyCache(s.rdb, testSlotTTLMinutes, int(testSlotTTL.Seconds()))
+
+	accountID := int64(200)
+	ok, _, err := cache.AcquireAccountSlot(s.ctx, accountID, 4, "req1")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+	ok, err = cache.IncrementAccountWaitCount(s.ctx, accountID, 5)
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+
+	exists, err := s.rdb.Exists(s.ctx, clusterAccountSlotKey(accountID), clusterAccountWaitKey(accountID)).Result()
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(2), exists, "cluster mode should use hash-tagged keys")
+
+	loadMap, err := cache.GetAccountsLoadBatch(s.ctx, []service.AccountWithConcurrency{
+		{ID: accountID, MaxConcurrency: 4},
+		{ID: accountID + 1, MaxConcurrency: 2},
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), loadMap, 2)
+	require.Equal(s.T(), 1, loadMap[accountID].CurrentConcurrency)
+	require.Equal(s.T(), 1, loadMap[accountID].WaitingCount)
+	require.Equal(s.T(), 50, loadMap[accountID].LoadRate)
+	require.Equal(s.T(), 0, loadMap[accountID+1].CurrentConcurrency)
+}
+
+func (s *ConcurrencyCacheSuite) TestReleaseAccountSlots_Batch() {
+	ok, _, err := s.cache.AcquireAccountSlot(s.ctx, 310, 2, "req-a")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+	ok, _, err = s.cache.AcquireAccountSlot(s.ctx, 310, 2, "req-b")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+	ok, _, err = s.cache.AcquireAccountSlot(s.ctx, 311, 2, "req-a")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+
+	err = s.cache.ReleaseAccountSlots(s.ctx, []service.SlotRelease{
+		{AccountID: 310, RequestID: "req-a"},
+		{AccountID: 311, RequestID: "req-a"},
+		{AccountID: 312, RequestID: "missing"},
+	})
+	require.NoError(s.T(), err)
+
+	cur, err := s.cache.GetAccountConcurrency(s.ctx, 310)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, cur)
+	cur, err = s.cache.GetAccountConcurrency(s.ctx, 311)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 0, cur)
+
+	require.NoError(s.T(), s.cache.ReleaseAccountSlots(s.ctx, nil))
+}
+
+func (s *ConcurrencyCacheSuite) TestRenewAccountSlot() {
+	accountID := int64(300)
+	slotKey := fmt.Sprintf("%s%d", accountSlotKeyPrefix, accountID)
+
+	ok, _, err := s.cache.AcquireAccountSlot(s.ctx, accountID, 2, "req-live")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+
+	// 模拟长时间运行：时间戳接近过期
+	oldScore := float64(time.Now().Unix() - int64(testSlotTTL.Seconds()) + 5)
+	require.NoError(s.T(), s.rdb.ZAdd(s.ctx, slotKey, redis.Z{Score: oldScore, Member: "req-live"}).Err())
+
+	require.NoError(s.T(), s.cache.RenewAccountSlot(s.ctx, accountID, "req-live"))
+	score, err := s.rdb.ZScore(s.ctx, slotKey, "req-live").Result()
+	require.NoError(s.T(), err)
+	require.Greater(s.T(), score, oldScore, "renew should refresh the slot timestamp")
+
+	// 已过期的槽位不能被续期，也不能被重新占用
+	expired := float64(time.Now().Unix() - int64(testSlotTTL.Seconds()) - 10)
+	require.NoError(s.T(), s.rdb.ZAdd(s.ctx, slotKey, redis.Z{Score: expired, Member: "req-stale"}).Err())
+	err = s.cache.RenewAccountSlot(s.ctx, accountID, "req-stale")
+	require.ErrorIs(s.T(), err, service.ErrConcurrencySlotExpired)
+
+	err = s.cache.RenewAccountSlot(s.ctx, accountID, "req-unknown")
+	require.ErrorIs(s.T(), err, service.ErrConcurrencySlotExpired)
+
+	cur, err := s.cache.GetAccountConcurrency(s.ctx, accountID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, cur)
+}
+
+func (s *ConcurrencyCacheSuite) TestRenewUserSlot() {
+	userID := int64(301)
+
+	ok, err := s.cache.AcquireUserSlot(s.ctx, userID, 1, "req-user")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+	require.NoError(s.T(), s.cache.RenewUserSlot(s.ctx, userID, "req-user"))
+
+	require.NoError(s.T(), s.cache.ReleaseUserSlot(s.ctx, userID, "req-user"))
+	require.ErrorIs(s.T(), s.cache.RenewUserSlot(s.ctx, userID, "req-user"), service.ErrConcurrencySlotExpired)
+}
+
+func (s *ConcurrencyCacheSuite) TestRenewGroupSlot() {
+	groupID := int64(302)
+
+	ok, err := s.cache.AcquireGroupSlot(s.ctx, groupID, 1, "req-group")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+	require.NoError(s.T(), s.cache.RenewGroupSlot(s.ctx, groupID, "req-group"))
+
+	require.NoError(s.T(), s.cache.ReleaseGroupSlot(s.ctx, groupID, "req-group"))
+	require.ErrorIs(s.T(), s.cache.RenewGroupSlot(s.ctx, groupID, "req-group"), service.ErrConcurrencySlotExpired)
+}
+
+func (s *ConcurrencyCacheSuite) TestGetAllAccountConcurrency() {
+	ok, _, err := s.cache.AcquireAccountSlot(s.ctx, 400, 5, "req1")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+	ok, _, err = s.cache.AcquireAccountSlot(s.ctx, 400, 5, "req2")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+	ok, _, err = s.cache.AcquireAccountSlot(s.ctx, 401, 5, "req1")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+
+	// 过期槽位不计入
+	expired := float64(time.Now().Unix() - int64(testSlotTTL.Seconds()) - 10)
+	require.NoError(s.T(), s.rdb.ZAdd(s.ctx, fmt.Sprintf("%s%d", accountSlotKeyPrefix, 402), redis.Z{Score: expired, Member: "old"}).Err())
+
+	all, err := s.cache.GetAllAccountConcurrency(s.ctx)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), map[int64]int{400: 2, 401: 1}, all)
+}
+
+func (s *ConcurrencyCacheSuite) TestAllowRate_SlidingWindow() {
+	key := "apikey:42"
+
+	for i := 0; i < 3; i++ {
+		allowed, retryAfter, err := s.cache.AllowRate(s.ctx, key, 3, 60)
+		require.NoError(s.T(), err)
+		require.True(s.T(), allowed, "request %d should be allowed", i+1)
+		require.Zero(s.T(), retryAfter)
+	}
+
+	allowed, retryAfter, err := s.cache.AllowRate(s.ctx, key, 3, 60)
+	require.NoError(s.T(), err)
+	require.False(s.T(), allowed, "4th request within window should be rejected")
+	require.Greater(s.T(), retryAfter, time.Duration(0))
+	require.LessOrEqual(s.T(), retryAfter, 60*time.Second)
+
+	cnt, err := s.rdb.ZCard(s.ctx, rateLimitKeyPrefix+key).Result()
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(3), cnt, "rejected requests must not be recorded")
+
+	_, _, err = s.cache.AllowRate(s.ctx, key, 0, 60)
+	require.Error(s.T(), err)
+}
+
 func TestConcurrencyCacheSuite(t *testing.T) {
 	suite.Run(t, new(ConcurrencyCacheSuite))
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 
@@ -13,11 +14,11 @@ import (
 const dashboardStatsCacheKey = "dashboard:stats:v1"
 
 type dashboardCache struct {
-	rdb       *redis.Client
+	rdb       redis.UniversalClient
 	keyPrefix string
 }
 
-func NewDashboardCache(rdb *redis.Client, cfg *config.Config) service.DashboardStatsCache {
+func NewDashboardCache(rdb redis.UniversalClient, cfg *config.Config) service.DashboardStatsCache {
 	prefix := "sub2api:"
 	if cfg != nil {
 		prefix = strings.TrimSpace(cfg.Dashboard.KeyPrefix)
@@ -34,7 +35,7 @@ func NewDashboardCache(rdb *redis.Client, cfg *config.Config) service.DashboardS
 func (c *dashboardCache) GetDashboardStats(ctx context.Context) (string, error) {
 	val, err := c.rdb.Get(ctx, c.buildKey()).Result()
 	if err != nil {
-		if err == redis.Nil {
+		if errors.Is(err, redis.Nil) {
 			return "", service.ErrDashboardStatsCacheMiss
 		}
 		return "", err
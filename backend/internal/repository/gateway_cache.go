@@ -12,10 +12,10 @@ import (
 const stickySessionPrefix = "sticky_session:"
 
 type gatewayCache struct {
-	rdb *redis.Client
+	rdb redis.UniversalClient
 }
 
-func NewGatewayCache(rdb *redis.Client) service.GatewayCache {
+func NewGatewayCache(rdb redis.UniversalClient) service.GatewayCache {
 	return &gatewayCache{rdb: rdb}
 }
 
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+)
+
+// opsErrorLogChannel Redis Pub/Sub 频道，广播新写入的错误日志
+const opsErrorLogChannel = "ops:error_logs"
+
+// 订阅端缓冲，慢消费者超出后丢弃消息而不是阻塞 Redis 连接
+const opsErrorLogSubscriberBuffer = 64
+
+type opsErrorLogStream struct {
+	rdb redis.UniversalClient
+}
+
+// NewOpsErrorLogStream 未配置 Redis 时返回 nil，实时错误推送随之关闭
+func NewOpsErrorLogStream(rdb redis.UniversalClient) service.OpsErrorLogStream {
+	if rdb == nil {
+		return nil
+	}
+	return &opsErrorLogStream{rdb: rdb}
+}
+
+func (s *opsErrorLogStream) PublishErrorLog(ctx context.Context, entry *service.OpsErrorLog) error {
+	if entry == nil {
+		return nil
+	}
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.rdb.Publish(ctx, opsErrorLogChannel, payload).Err()
+}
+
+func (s *opsErrorLogStream) SubscribeErrorLogs(ctx context.Context) (<-chan *service.OpsErrorLog, error) {
+	pubsub := s.rdb.Subscribe(ctx, opsErrorLogChannel)
+	// 等待订阅确认，连接失败时直接返回错误
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	out := make(chan *service.OpsErrorLog, opsErrorLogSubscriberBuffer)
+	go func() {
+		defer close(out)
+		defer func() { _ = pubsub.Close() }()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				var entry service.OpsErrorLog
+				if err := json.Unmarshal([]byte(msg.Payload), &entry); err != nil {
+					log.Printf("[OpsErrorLogStream] decode message failed: %v", err)
+					continue
+				}
+				select {
+				case out <- &entry:
+				default:
+				}
+			}
+		}
+	}()
+	return out, nil
+}
@@ -120,28 +120,25 @@ func (r *opsRepository) ListErrorLogs(ctx context.Context, filter *service.OpsEr
 		filter = &service.OpsErrorLogFilter{}
 	}
 
-	page := filter.Page
-	if page <= 0 {
-		page = 1
-	}
-	pageSize := filter.PageSize
-	if pageSize <= 0 {
-		pageSize = 20
-	}
-	if pageSize > 500 {
-		pageSize = 500
-	}
+	page, pageSize := filter.Normalize()
 
 	where, args := buildOpsErrorLogsWhere(filter)
-	countSQL := "SELECT COUNT(*) FROM ops_error_logs e " + where
 
 	var total int
-	if err := r.db.QueryRowContext(ctx, countSQL, args...).Scan(&total); err != nil {
-		return nil, err
+	if !filter.SkipTotal {
+		countSQL := "SELECT COUNT(*) FROM ops_error_logs e " + where
+		if err := r.db.QueryRowContext(ctx, countSQL, args...).Scan(&total); err != nil {
+			return nil, err
+		}
 	}
 
 	offset := (page - 1) * pageSize
-	argsWithLimit := append(args, pageSize, offset)
+	limit := pageSize
+	if filter.SkipTotal {
+		// Fetch one extra row to tell whether another page exists without COUNT(*).
+		limit++
+	}
+	argsWithLimit := append(args, limit, offset)
 	selectSQL := `
 SELECT
   e.id,
@@ -282,6 +279,13 @@ LIMIT $` + itoa(len(args)+1) + ` OFFSET $` + itoa(len(args)+2)
 		return nil, err
 	}
 
+	if filter.SkipTotal {
+		total = offset + len(out)
+		if len(out) > pageSize {
+			out = out[:pageSize]
+		}
+	}
+
 	return &service.OpsErrorLogList{
 		Errors:   out,
 		Total:    total,
@@ -960,7 +964,7 @@ func buildOpsErrorLogsWhere(filter *service.OpsErrorLogFilter) (string, []any) {
 		// Keep time-window semantics consistent with other ops queries: [start, end)
 		clauses = append(clauses, "e.created_at < $"+itoa(len(args)))
 	}
-	if p := strings.TrimSpace(filter.Platform); p != "" {
+	if p := strings.TrimSpace(string(filter.Platform)); p != "" {
 		args = append(args, p)
 		clauses = append(clauses, "platform = $"+itoa(len(args)))
 	}
@@ -1061,6 +1065,13 @@ func opsNullString(v any) any {
 			return sql.NullString{}
 		}
 		return sql.NullString{String: strings.TrimSpace(s), Valid: true}
+	case service.Platform:
+		return opsNullString(string(s))
+	case *service.Platform:
+		if s == nil {
+			return sql.NullString{}
+		}
+		return opsNullString(string(*s))
 	default:
 		return sql.NullString{}
 	}
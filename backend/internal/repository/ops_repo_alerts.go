@@ -351,6 +351,7 @@ SELECT
   fired_at,
   resolved_at,
   email_sent,
+  webhook_sent,
   created_at
 FROM ops_alert_events
 ` + where + `
@@ -383,6 +384,7 @@ LIMIT ` + limitArg
 			&ev.FiredAt,
 			&resolvedAt,
 			&ev.EmailSent,
+			&ev.WebhookSent,
 			&ev.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -435,6 +437,7 @@ SELECT
   fired_at,
   resolved_at,
   email_sent,
+  webhook_sent,
   created_at
 FROM ops_alert_events
 WHERE id = $1`
@@ -472,6 +475,7 @@ SELECT
   fired_at,
   resolved_at,
   email_sent,
+  webhook_sent,
   created_at
 FROM ops_alert_events
 WHERE rule_id = $1 AND status = $2
@@ -511,6 +515,7 @@ SELECT
   fired_at,
   resolved_at,
   email_sent,
+  webhook_sent,
   created_at
 FROM ops_alert_events
 WHERE rule_id = $1
@@ -554,9 +559,10 @@ INSERT INTO ops_alert_events (
   fired_at,
   resolved_at,
   email_sent,
+  webhook_sent,
   created_at
 ) VALUES (
-  $1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,NOW()
+  $1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,NOW()
 )
 RETURNING
   id,
@@ -571,6 +577,7 @@ RETURNING
   fired_at,
   resolved_at,
   email_sent,
+  webhook_sent,
   created_at`
 
 	row := r.db.QueryRowContext(
@@ -587,6 +594,7 @@ RETURNING
 		event.FiredAt,
 		opsNullTime(event.ResolvedAt),
 		event.EmailSent,
+		event.WebhookSent,
 	)
 	return scanOpsAlertEvent(row)
 }
@@ -624,6 +632,18 @@ func (r *opsRepository) UpdateAlertEventEmailSent(ctx context.Context, eventID i
 	return err
 }
 
+func (r *opsRepository) UpdateAlertEventWebhookSent(ctx context.Context, eventID int64, webhookSent bool) error {
+	if r == nil || r.db == nil {
+		return fmt.Errorf("nil ops repository")
+	}
+	if eventID <= 0 {
+		return fmt.Errorf("invalid event id")
+	}
+
+	_, err := r.db.ExecContext(ctx, "UPDATE ops_alert_events SET webhook_sent = $2 WHERE id = $1", eventID, webhookSent)
+	return err
+}
+
 type opsAlertEventRow interface {
 	Scan(dest ...any) error
 }
@@ -763,6 +783,7 @@ func scanOpsAlertEvent(row opsAlertEventRow) (*service.OpsAlertEvent, error) {
 		&ev.FiredAt,
 		&resolvedAt,
 		&ev.EmailSent,
+		&ev.WebhookSent,
 		&ev.CreatedAt,
 	); err != nil {
 		return nil, err
@@ -826,7 +847,7 @@ func buildOpsAlertEventsWhere(filter *service.OpsAlertEventFilter) (string, []an
 		clauses = append(clauses, fmt.Sprintf("(fired_at < %s OR (fired_at = %s AND id < %s))", tsArg, tsArg, idArg))
 	}
 	// Dimensions are stored in JSONB. We filter best-effort without requiring GIN indexes.
-	if platform := strings.TrimSpace(filter.Platform); platform != "" {
+	if platform := strings.TrimSpace(string(filter.Platform)); platform != "" {
 		args = append(args, platform)
 		clauses = append(clauses, "(dimensions->>'platform') = $"+itoa(len(args)))
 	}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/lib/pq"
+)
+
+// InsertAccountConcurrencySamples stores one minute of per-account concurrency samples.
+// Like InsertSystemMetrics, replays for the same (account_id, created_at) are ignored.
+func (r *opsRepository) InsertAccountConcurrencySamples(ctx context.Context, createdAt time.Time, samples []service.OpsAccountConcurrencySample) error {
+	if r == nil || r.db == nil {
+		return fmt.Errorf("nil ops repository")
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+	if createdAt.IsZero() {
+		createdAt = time.Now().UTC()
+	}
+
+	accountIDs := make([]int64, 0, len(samples))
+	current := make([]int64, 0, len(samples))
+	maxConc := make([]int64, 0, len(samples))
+	waiting := make([]int64, 0, len(samples))
+	for _, s := range samples {
+		if s.AccountID <= 0 {
+			continue
+		}
+		accountIDs = append(accountIDs, s.AccountID)
+		current = append(current, int64(s.CurrentConcurrency))
+		maxConc = append(maxConc, int64(s.MaxConcurrency))
+		waiting = append(waiting, int64(s.WaitingCount))
+	}
+	if len(accountIDs) == 0 {
+		return nil
+	}
+
+	q := `
+INSERT INTO ops_account_concurrency_samples (
+  created_at,
+  account_id,
+  current_concurrency,
+  max_concurrency,
+  waiting_count
+)
+SELECT $1, t.account_id, t.current_concurrency, t.max_concurrency, t.waiting_count
+FROM unnest($2::bigint[], $3::int[], $4::int[], $5::int[])
+  AS t(account_id, current_concurrency, max_concurrency, waiting_count)
+ON CONFLICT (account_id, created_at) DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, q,
+		createdAt.UTC(),
+		pq.Array(accountIDs),
+		pq.Array(current),
+		pq.Array(maxConc),
+		pq.Array(waiting),
+	)
+	return err
+}
+
+func (r *opsRepository) GetAccountConcurrencyHistory(ctx context.Context, filter *service.OpsAccountConcurrencyHistoryFilter) ([]*service.OpsAccountConcurrencyHourlyPoint, error) {
+	if r == nil || r.db == nil {
+		return nil, fmt.Errorf("nil ops repository")
+	}
+	if filter == nil {
+		return nil, fmt.Errorf("nil filter")
+	}
+	if filter.StartTime.IsZero() || filter.EndTime.IsZero() {
+		return nil, fmt.Errorf("start_time/end_time required")
+	}
+
+	args := []any{filter.StartTime.UTC(), filter.EndTime.UTC()}
+	where := "created_at >= $1 AND created_at < $2"
+	if filter.AccountID != nil && *filter.AccountID > 0 {
+		args = append(args, *filter.AccountID)
+		where += " AND account_id = $3"
+	}
+
+	q := `
+SELECT
+  account_id,
+  date_trunc('hour', created_at) AS bucket_start,
+  COALESCE(MAX(current_concurrency), 0) AS peak_concurrency,
+  COALESCE(AVG(current_concurrency), 0) AS avg_concurrency,
+  COALESCE(MAX(waiting_count), 0) AS peak_waiting,
+  COALESCE((ARRAY_AGG(max_concurrency ORDER BY created_at DESC))[1], 0) AS max_concurrency,
+  COUNT(*) AS sample_count
+FROM ops_account_concurrency_samples
+WHERE ` + where + `
+GROUP BY account_id, bucket_start
+ORDER BY bucket_start ASC, account_id ASC`
+
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make([]*service.OpsAccountConcurrencyHourlyPoint, 0, 64)
+	for rows.Next() {
+		var p service.OpsAccountConcurrencyHourlyPoint
+		var avg float64
+		if err := rows.Scan(
+			&p.AccountID,
+			&p.BucketStart,
+			&p.PeakConcurrency,
+			&avg,
+			&p.PeakWaiting,
+			&p.MaxConcurrency,
+			&p.SampleCount,
+		); err != nil {
+			return nil, err
+		}
+		p.AvgConcurrency = roundTo1DP(avg)
+		p.BucketStart = p.BucketStart.UTC()
+		out = append(out, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -93,7 +93,7 @@ func (r *opsRepository) getDashboardOverviewRaw(ctx context.Context, filter *ser
 	return &service.OpsDashboardOverview{
 		StartTime: start,
 		EndTime:   end,
-		Platform:  strings.TrimSpace(filter.Platform),
+		Platform:  strings.TrimSpace(string(filter.Platform)),
 		GroupID:   filter.GroupID,
 
 		SuccessCount:         successCount,
@@ -254,7 +254,7 @@ func (r *opsRepository) getDashboardOverviewPreaggregated(ctx context.Context, f
 	return &service.OpsDashboardOverview{
 		StartTime: start,
 		EndTime:   end,
-		Platform:  strings.TrimSpace(filter.Platform),
+		Platform:  strings.TrimSpace(string(filter.Platform)),
 		GroupID:   filter.GroupID,
 
 		SuccessCount:         successCount,
@@ -332,7 +332,7 @@ func (r *opsRepository) listHourlyMetricsRows(ctx context.Context, filter *servi
 	platform := ""
 	groupID := (*int64)(nil)
 	if filter != nil {
-		platform = strings.TrimSpace(strings.ToLower(filter.Platform))
+		platform = strings.TrimSpace(strings.ToLower(string(filter.Platform)))
 		groupID = filter.GroupID
 	}
 
@@ -918,11 +918,14 @@ FROM (
 	return roundTo1DP(float64(maxPerMinute.Int64) / 60.0), nil
 }
 
+// opsUsagePlatformJoin resolves a usage row's platform from its group, falling back to its account.
+const opsUsagePlatformJoin = "LEFT JOIN groups g ON g.id = ul.group_id LEFT JOIN accounts a ON a.id = ul.account_id"
+
 func buildUsageWhere(filter *service.OpsDashboardFilter, start, end time.Time, startIndex int) (join string, where string, args []any, nextIndex int) {
 	platform := ""
 	groupID := (*int64)(nil)
 	if filter != nil {
-		platform = strings.TrimSpace(strings.ToLower(filter.Platform))
+		platform = strings.TrimSpace(strings.ToLower(string(filter.Platform)))
 		groupID = filter.GroupID
 	}
 
@@ -945,7 +948,7 @@ func buildUsageWhere(filter *service.OpsDashboardFilter, start, end time.Time, s
 	if platform != "" {
 		// Prefer group.platform when available; fall back to account.platform so we don't
 		// drop rows where group_id is NULL.
-		join = "LEFT JOIN groups g ON g.id = ul.group_id LEFT JOIN accounts a ON a.id = ul.account_id"
+		join = opsUsagePlatformJoin
 		args = append(args, platform)
 		clauses = append(clauses, fmt.Sprintf("COALESCE(NULLIF(g.platform,''), a.platform) = $%d", idx))
 		idx++
@@ -959,7 +962,7 @@ func buildErrorWhere(filter *service.OpsDashboardFilter, start, end time.Time, s
 	platform := ""
 	groupID := (*int64)(nil)
 	if filter != nil {
-		platform = strings.TrimSpace(strings.ToLower(filter.Platform))
+		platform = strings.TrimSpace(strings.ToLower(string(filter.Platform)))
 		groupID = filter.GroupID
 	}
 
@@ -0,0 +1,58 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpsRepositoryListErrorLogs_SkipTotal(t *testing.T) {
+	ctx := context.Background()
+	repo := NewOpsRepository(integrationDB)
+
+	marker := "skip-total-" + time.Now().Format("150405.000000")
+	t.Cleanup(func() {
+		_, _ = integrationDB.ExecContext(ctx, "DELETE FROM ops_error_logs WHERE request_id LIKE $1", marker+"%")
+	})
+
+	base := time.Now().UTC().Add(-time.Minute)
+	for i := 0; i < 5; i++ {
+		_, err := repo.InsertErrorLog(ctx, &service.OpsInsertErrorLogInput{
+			RequestID:  fmt.Sprintf("%s-%d", marker, i),
+			ErrorPhase: "internal",
+			ErrorType:  "api_error",
+			Severity:   "P2",
+			StatusCode: 500,
+			CreatedAt:  base.Add(time.Duration(i) * time.Second),
+		})
+		require.NoError(t, err)
+	}
+
+	// 默认路径：精确 COUNT，分页行数不变
+	list, err := repo.ListErrorLogs(ctx, &service.OpsErrorLogFilter{Query: marker, Page: 1, PageSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, 5, list.Total)
+	require.Len(t, list.Errors, 2)
+	require.Equal(t, marker+"-4", list.Errors[0].RequestID)
+
+	// 还有下一页：多取的一行被裁掉，total 为下界 offset+len+1
+	list, err = repo.ListErrorLogs(ctx, &service.OpsErrorLogFilter{Query: marker, Page: 1, PageSize: 2, SkipTotal: true})
+	require.NoError(t, err)
+	require.Len(t, list.Errors, 2)
+	require.Equal(t, marker+"-4", list.Errors[0].RequestID)
+	require.Equal(t, marker+"-3", list.Errors[1].RequestID)
+	require.Equal(t, 3, list.Total)
+
+	// 最后一页：没有多余行，total 即精确值
+	list, err = repo.ListErrorLogs(ctx, &service.OpsErrorLogFilter{Query: marker, Page: 3, PageSize: 2, SkipTotal: true})
+	require.NoError(t, err)
+	require.Len(t, list.Errors, 1)
+	require.Equal(t, marker+"-0", list.Errors[0].RequestID)
+	require.Equal(t, 5, list.Total)
+}
@@ -71,7 +71,7 @@ ORDER BY 3 ASC`
 	return &service.OpsLatencyHistogramResponse{
 		StartTime:     start,
 		EndTime:       end,
-		Platform:      strings.TrimSpace(filter.Platform),
+		Platform:      strings.TrimSpace(string(filter.Platform)),
 		GroupID:       filter.GroupID,
 		TotalRequests: total,
 		Buckets:       buckets,
@@ -9,6 +9,9 @@ import (
 	"github.com/Wei-Shaw/sub2api/internal/service"
 )
 
+// InsertSystemMetrics stores one metrics snapshot.
+// Snapshots are idempotent per (created_at, window_minutes, platform, group_id): replays from a retry
+// or a redundant collector instance are ignored (see uq_ops_system_metrics_snapshot).
 func (r *opsRepository) InsertSystemMetrics(ctx context.Context, input *service.OpsInsertSystemMetricsInput) error {
 	if r == nil || r.db == nil {
 		return fmt.Errorf("nil ops repository")
@@ -89,7 +92,8 @@ INSERT INTO ops_system_metrics (
   $33,$34,
   $35,$36,$37,
   $38,$39
-)`
+)
+ON CONFLICT (created_at, window_minutes, COALESCE(platform, ''), COALESCE(group_id, 0)) DO NOTHING`
 
 	_, err := r.db.ExecContext(
 		ctx,
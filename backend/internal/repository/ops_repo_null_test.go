@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpsNullString_Platform(t *testing.T) {
+	platform := service.Platform(service.PlatformGemini)
+	empty := service.Platform(" ")
+
+	require.Equal(t, sql.NullString{String: "gemini", Valid: true}, opsNullString(platform))
+	require.Equal(t, sql.NullString{String: "gemini", Valid: true}, opsNullString(&platform))
+	require.Equal(t, sql.NullString{}, opsNullString(empty))
+	require.Equal(t, sql.NullString{}, opsNullString((*service.Platform)(nil)))
+}
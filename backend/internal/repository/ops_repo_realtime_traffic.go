@@ -113,7 +113,7 @@ FROM combined`
 	return &service.OpsRealtimeTrafficSummary{
 		StartTime: start,
 		EndTime:   end,
-		Platform:  strings.TrimSpace(filter.Platform),
+		Platform:  strings.TrimSpace(string(filter.Platform)),
 		GroupID:   filter.GroupID,
 		QPS: service.OpsRateSummary{
 			Current: qpsCurrent,
@@ -37,7 +37,7 @@ func (r *opsRepository) ListRequestDetails(ctx context.Context, filter *service.
 			addCondition(fmt.Sprintf("kind = $%d", len(args)+1), kind)
 		}
 
-		if platform := strings.TrimSpace(strings.ToLower(filter.Platform)); platform != "" {
+		if platform := strings.TrimSpace(strings.ToLower(string(filter.Platform))); platform != "" {
 			addCondition(fmt.Sprintf("platform = $%d", len(args)+1), platform)
 		}
 		if filter.GroupID != nil && *filter.GroupID > 0 {
@@ -119,7 +119,7 @@ ORDER BY bucket ASC`
 
 	platform := ""
 	if filter != nil {
-		platform = strings.TrimSpace(strings.ToLower(filter.Platform))
+		platform = strings.TrimSpace(strings.ToLower(string(filter.Platform)))
 	}
 	groupID := (*int64)(nil)
 	if filter != nil {
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/service"
@@ -48,3 +49,123 @@ func (r *opsRepository) GetWindowStats(ctx context.Context, filter *service.OpsD
 		TokenConsumed:   tokenConsumed,
 	}, nil
 }
+
+// opsWindowStatsUnknownKey labels rows whose platform/model is empty.
+const opsWindowStatsUnknownKey = "unknown"
+
+// GetWindowStatsBreakdown returns window stats grouped by platform or model.
+// Keys with no traffic in the window are omitted.
+func (r *opsRepository) GetWindowStatsBreakdown(ctx context.Context, filter *service.OpsDashboardFilter, dimension string) (map[string]*service.OpsWindowStats, error) {
+	if r == nil || r.db == nil {
+		return nil, fmt.Errorf("nil ops repository")
+	}
+	if filter == nil {
+		return nil, fmt.Errorf("nil filter")
+	}
+	if filter.StartTime.IsZero() || filter.EndTime.IsZero() {
+		return nil, fmt.Errorf("start_time/end_time required")
+	}
+
+	var usageKey, errorKey string
+	switch dimension {
+	case service.OpsWindowStatsByPlatform:
+		usageKey = "COALESCE(NULLIF(g.platform,''), a.platform, '')"
+		errorKey = "COALESCE(platform, '')"
+	case service.OpsWindowStatsByModel:
+		usageKey = "COALESCE(ul.model, '')"
+		errorKey = "COALESCE(model, '')"
+	default:
+		return nil, fmt.Errorf("unsupported breakdown dimension: %q", dimension)
+	}
+
+	start := filter.StartTime.UTC()
+	end := filter.EndTime.UTC()
+	if start.After(end) {
+		return nil, fmt.Errorf("start_time must be <= end_time")
+	}
+	if end.Sub(start) > 24*time.Hour {
+		return nil, fmt.Errorf("window too large")
+	}
+
+	out := make(map[string]*service.OpsWindowStats)
+	statsFor := func(key string) *service.OpsWindowStats {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			key = opsWindowStatsUnknownKey
+		}
+		stats, ok := out[key]
+		if !ok {
+			stats = &service.OpsWindowStats{StartTime: start, EndTime: end}
+			out[key] = stats
+		}
+		return stats
+	}
+
+	// The platform key always needs the group/account join; buildUsageWhere only adds it for platform filters.
+	_, usageWhere, usageArgs, _ := buildUsageWhere(filter, start, end, 1)
+	usageQ := `
+SELECT
+  ` + usageKey + ` AS k,
+  COUNT(*) AS success_count,
+  COALESCE(SUM(input_tokens + output_tokens + cache_creation_tokens + cache_read_tokens), 0) AS token_consumed
+FROM usage_logs ul
+` + opsUsagePlatformJoin + `
+` + usageWhere + `
+GROUP BY 1`
+
+	rows, err := r.db.QueryContext(ctx, usageQ, usageArgs...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var key string
+		var successCount, tokenConsumed int64
+		if err := rows.Scan(&key, &successCount, &tokenConsumed); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		if successCount == 0 {
+			continue
+		}
+		stats := statsFor(key)
+		stats.SuccessCount += successCount
+		stats.TokenConsumed += tokenConsumed
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	errorWhere, errorArgs, _ := buildErrorWhere(filter, start, end, 1)
+	errorQ := `
+SELECT
+  ` + errorKey + ` AS k,
+  COUNT(*) AS error_total
+FROM ops_error_logs
+` + errorWhere + `
+  AND COALESCE(status_code, 0) >= 400
+GROUP BY 1`
+
+	rows, err = r.db.QueryContext(ctx, errorQ, errorArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var key string
+		var errorTotal int64
+		if err := rows.Scan(&key, &errorTotal); err != nil {
+			return nil, err
+		}
+		if errorTotal == 0 {
+			continue
+		}
+		statsFor(key).ErrorCountTotal += errorTotal
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -16,10 +16,10 @@ func proxyLatencyKey(proxyID int64) string {
 }
 
 type proxyLatencyCache struct {
-	rdb *redis.Client
+	rdb redis.UniversalClient
 }
 
-func NewProxyLatencyCache(rdb *redis.Client) service.ProxyLatencyCache {
+func NewProxyLatencyCache(rdb redis.UniversalClient) service.ProxyLatencyCache {
 	return &proxyLatencyCache{rdb: rdb}
 }
 
@@ -34,7 +34,7 @@ func (c *proxyLatencyCache) GetProxyLatencies(ctx context.Context, proxyIDs []in
 		keys = append(keys, proxyLatencyKey(id))
 	}
 
-	values, err := c.rdb.MGet(ctx, keys...).Result()
+	values, err := mgetValues(ctx, c.rdb, keys...)
 	if err != nil {
 		return results, err
 	}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -26,20 +27,23 @@ func redeemLockKey(code string) string {
 }
 
 type redeemCache struct {
-	rdb *redis.Client
+	rdb redis.UniversalClient
 }
 
-func NewRedeemCache(rdb *redis.Client) service.RedeemCache {
+func NewRedeemCache(rdb redis.UniversalClient) service.RedeemCache {
 	return &redeemCache{rdb: rdb}
 }
 
 func (c *redeemCache) GetRedeemAttemptCount(ctx context.Context, userID int64) (int, error) {
 	key := redeemRateLimitKey(userID)
 	count, err := c.rdb.Get(ctx, key).Int()
-	if err == redis.Nil {
+	if errors.Is(err, redis.Nil) {
 		return 0, nil
 	}
-	return count, err
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
 }
 
 func (c *redeemCache) IncrementRedeemAttemptCount(ctx context.Context, userID int64) error {
@@ -19,7 +19,12 @@ import (
 // 1. PoolSize: 控制最大并发连接数（默认 128）
 // 2. MinIdleConns: 保持最小空闲连接，减少冷启动延迟（默认 10）
 // 3. DialTimeout/ReadTimeout/WriteTimeout: 精确控制各阶段超时
-func InitRedis(cfg *config.Config) *redis.Client {
+//
+// 开启 redis.cluster_mode 时返回 ClusterClient，host/port 作为种子节点，其余节点由集群拓扑自动发现。
+func InitRedis(cfg *config.Config) redis.UniversalClient {
+	if cfg.Redis.ClusterMode {
+		return redis.NewClusterClient(buildRedisClusterOptions(cfg))
+	}
 	return redis.NewClient(buildRedisOptions(cfg))
 }
 
@@ -37,3 +42,17 @@ func buildRedisOptions(cfg *config.Config) *redis.Options {
 		MinIdleConns: cfg.Redis.MinIdleConns,                                     // 最小空闲连接
 	}
 }
+
+// buildRedisClusterOptions 构建 Redis Cluster 连接选项
+// Cluster 不支持 SELECT，DB 固定为 0（由配置校验保证）
+func buildRedisClusterOptions(cfg *config.Config) *redis.ClusterOptions {
+	return &redis.ClusterOptions{
+		Addrs:        []string{cfg.Redis.Address()},
+		Password:     cfg.Redis.Password,
+		DialTimeout:  time.Duration(cfg.Redis.DialTimeoutSeconds) * time.Second,
+		ReadTimeout:  time.Duration(cfg.Redis.ReadTimeoutSeconds) * time.Second,
+		WriteTimeout: time.Duration(cfg.Redis.WriteTimeoutSeconds) * time.Second,
+		PoolSize:     cfg.Redis.PoolSize,
+		MinIdleConns: cfg.Redis.MinIdleConns,
+	}
+}
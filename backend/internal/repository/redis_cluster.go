@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis Cluster 兼容性说明：
+// Redis Cluster 要求单个 Lua 脚本 / 事务访问的所有键位于同一个 hash slot，
+// 且脚本访问的键必须全部通过 KEYS 传入。集群模式下：
+// 1. 需要在同一脚本中访问的键使用相同的 hash tag（{...}），保证落在同一 slot
+// 2. 禁止在 Lua 内拼接键名（单机模式下的批量脚本会改为逐账号流水线执行）
+// 3. MGET 等多键命令同样受 slot 限制，批量读取统一走 mgetValues
+
+// mgetValues 批量读取多个键，返回值语义与 MGET 一致（未命中为 nil）。
+// ClusterClient 的 MGET 要求所有键位于同一 slot，集群模式下改为流水线逐键 GET，由客户端按节点分发。
+func mgetValues(ctx context.Context, rdb redis.UniversalClient, keys ...string) ([]any, error) {
+	if _, ok := rdb.(*redis.ClusterClient); !ok {
+		return rdb.MGet(ctx, keys...).Result()
+	}
+
+	pipe := rdb.Pipeline()
+	cmds := make([]*redis.StringCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.Get(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	values := make([]any, len(keys))
+	for i, cmd := range cmds {
+		val, err := cmd.Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[i] = val
+	}
+	return values, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// redisClusterSlots Redis Cluster 固定 slot 数量
+const redisClusterSlots = 16384
+
+// redisKeySlot 计算键所属的 hash slot，用于在测试中校验键布局（与 Redis Cluster 规范一致：CRC16-XMODEM(tag) mod 16384）
+// 若键中包含非空 hash tag（第一个 '{' 与其后第一个 '}' 之间的内容），仅对 tag 部分求值。
+func redisKeySlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16XModem(key) % redisClusterSlots)
+}
+
+// crc16XModem CRC16-CCITT (XMODEM) 实现，Redis Cluster 使用该算法计算 slot
+func crc16XModem(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for b := 0; b < 8; b++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+func TestRedisKeySlot(t *testing.T) {
+	// 参考值来自 Redis CLUSTER KEYSLOT
+	require.Equal(t, 12739, redisKeySlot("123456789"))
+	require.Equal(t, 15495, redisKeySlot("a"))
+	// hash tag 仅对花括号内部求值
+	require.Equal(t, redisKeySlot("user1000"), redisKeySlot("{user1000}.following"))
+	// 空 hash tag 按整个键求值
+	require.Equal(t, int(crc16XModem("foo{}{bar}")%redisClusterSlots), redisKeySlot("foo{}{bar}"))
+}
+
+func TestConcurrencyCacheKeyPlacement(t *testing.T) {
+	cc := NewClusterConcurrencyCache(nil, 0, 0).(*concurrencyCache)
+	standalone := NewConcurrencyCache(nil, 0, 0).(*concurrencyCache)
+
+	// getAccountLoadScript 同时访问槽位键与等待键，集群模式下必须位于同一 slot
+	for _, id := range []int64{math.MinInt64, -1, 0, 1, 7, 42, 1 << 40, math.MaxInt64} {
+		require.Equal(t, redisKeySlot(cc.accountSlotKey(id)), redisKeySlot(cc.accountWaitKey(id)), "account %d", id)
+		require.Equal(t, []string{cc.accountSlotKey(id), cc.accountWaitKey(id)}, cc.accountLoadKeys(id))
+	}
+	require.Equal(t, "concurrency:account:{7}", cc.accountSlotKey(7))
+	require.Equal(t, "wait:account:{7}", cc.accountWaitKey(7))
+
+	// 单机模式保持原有键格式（不带 hash tag）
+	require.Equal(t, []string{"concurrency:account:7", "wait:account:7"}, standalone.accountLoadKeys(7))
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,3 +34,33 @@ func TestBuildRedisOptions(t *testing.T) {
 	require.Equal(t, 100, opts.PoolSize)
 	require.Equal(t, 10, opts.MinIdleConns)
 }
+
+func TestInitRedis_ClusterMode(t *testing.T) {
+	cfg := &config.Config{
+		Redis: config.RedisConfig{
+			Host:                "redis-node-1",
+			Port:                7000,
+			Password:            "secret",
+			DialTimeoutSeconds:  5,
+			ReadTimeoutSeconds:  3,
+			WriteTimeoutSeconds: 4,
+			PoolSize:            100,
+			MinIdleConns:        10,
+			ClusterMode:         true,
+		},
+	}
+
+	opts := buildRedisClusterOptions(cfg)
+	require.Equal(t, []string{"redis-node-1:7000"}, opts.Addrs)
+	require.Equal(t, "secret", opts.Password)
+	require.Equal(t, 5*time.Second, opts.DialTimeout)
+	require.Equal(t, 3*time.Second, opts.ReadTimeout)
+	require.Equal(t, 4*time.Second, opts.WriteTimeout)
+	require.Equal(t, 100, opts.PoolSize)
+	require.Equal(t, 10, opts.MinIdleConns)
+
+	rdb := InitRedis(cfg)
+	defer func() { _ = rdb.Close() }()
+	_, ok := rdb.(*redis.ClusterClient)
+	require.True(t, ok)
+}
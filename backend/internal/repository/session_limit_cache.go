@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -143,13 +144,13 @@ var (
 )
 
 type sessionLimitCache struct {
-	rdb                *redis.Client
+	rdb                redis.UniversalClient
 	defaultIdleTimeout time.Duration // 默认空闲超时（用于 GetActiveSessionCount）
 }
 
 // NewSessionLimitCache 创建会话限制缓存
 // defaultIdleTimeoutMinutes: 默认空闲超时时间（分钟），用于无参数查询
-func NewSessionLimitCache(rdb *redis.Client, defaultIdleTimeoutMinutes int) service.SessionLimitCache {
+func NewSessionLimitCache(rdb redis.UniversalClient, defaultIdleTimeoutMinutes int) service.SessionLimitCache {
 	if defaultIdleTimeoutMinutes <= 0 {
 		defaultIdleTimeoutMinutes = 5 // 默认 5 分钟
 	}
@@ -268,7 +269,7 @@ func (c *sessionLimitCache) IsSessionActive(ctx context.Context, accountID int64
 func (c *sessionLimitCache) GetWindowCost(ctx context.Context, accountID int64) (float64, bool, error) {
 	key := windowCostKey(accountID)
 	val, err := c.rdb.Get(ctx, key).Float64()
-	if err == redis.Nil {
+	if errors.Is(err, redis.Nil) {
 		return 0, false, nil // 缓存未命中
 	}
 	if err != nil {
@@ -295,8 +296,8 @@ func (c *sessionLimitCache) GetWindowCostBatch(ctx context.Context, accountIDs [
 		keys[i] = windowCostKey(accountID)
 	}
 
-	// 使用 MGET 批量获取
-	vals, err := c.rdb.MGet(ctx, keys...).Result()
+	// 使用 MGET 批量获取（集群模式下按节点流水线读取）
+	vals, err := mgetValues(ctx, c.rdb, keys...)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -34,10 +35,10 @@ var tempUnschedSetScript = redis.NewScript(`
 `)
 
 type tempUnschedCache struct {
-	rdb *redis.Client
+	rdb redis.UniversalClient
 }
 
-func NewTempUnschedCache(rdb *redis.Client) service.TempUnschedCache {
+func NewTempUnschedCache(rdb redis.UniversalClient) service.TempUnschedCache {
 	return &tempUnschedCache{rdb: rdb}
 }
 
@@ -69,7 +70,7 @@ func (c *tempUnschedCache) GetTempUnsched(ctx context.Context, accountID int64)
 	key := fmt.Sprintf("%s%d", tempUnschedPrefix, accountID)
 
 	val, err := c.rdb.Get(ctx, key).Result()
-	if err == redis.Nil {
+	if errors.Is(err, redis.Nil) {
 		return nil, nil
 	}
 	if err != nil {
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -26,11 +27,11 @@ var timeoutCounterIncrScript = redis.NewScript(`
 `)
 
 type timeoutCounterCache struct {
-	rdb *redis.Client
+	rdb redis.UniversalClient
 }
 
 // NewTimeoutCounterCache 创建超时计数器缓存实例
-func NewTimeoutCounterCache(rdb *redis.Client) service.TimeoutCounterCache {
+func NewTimeoutCounterCache(rdb redis.UniversalClient) service.TimeoutCounterCache {
 	return &timeoutCounterCache{rdb: rdb}
 }
 
@@ -57,7 +58,7 @@ func (c *timeoutCounterCache) GetTimeoutCount(ctx context.Context, accountID int
 	key := fmt.Sprintf("%s%d", timeoutCounterPrefix, accountID)
 
 	val, err := c.rdb.Get(ctx, key).Int64()
-	if err == redis.Nil {
+	if errors.Is(err, redis.Nil) {
 		return 0, nil
 	}
 	if err != nil {
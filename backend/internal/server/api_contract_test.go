@@ -598,6 +598,10 @@ func (stubApiKeyCache) DeleteCreateAttemptCount(ctx context.Context, userID int6
 	return nil
 }
 
+func (stubApiKeyCache) CheckAndIncrementCreate(ctx context.Context, userID int64, limit int) (bool, int, time.Time, error) {
+	return true, limit, time.Time{}, nil
+}
+
 func (stubApiKeyCache) IncrementDailyUsage(ctx context.Context, apiKey string) error {
 	return nil
 }
@@ -72,8 +72,11 @@ func registerOpsRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 	{
 		// Realtime ops signals
 		ops.GET("/concurrency", h.Admin.Ops.GetConcurrencyStats)
+		ops.GET("/concurrency/history", h.Admin.Ops.GetAccountConcurrencyHistory)
 		ops.GET("/account-availability", h.Admin.Ops.GetAccountAvailability)
 		ops.GET("/realtime-traffic", h.Admin.Ops.GetRealtimeTrafficSummary)
+		ops.GET("/window-rates", h.Admin.Ops.GetWindowRates)
+		ops.GET("/window-breakdown", h.Admin.Ops.GetWindowBreakdown)
 
 		// Alerts (rules + events)
 		ops.GET("/alert-rules", h.Admin.Ops.ListAlertRules)
@@ -107,10 +110,11 @@ func registerOpsRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 			settings.PUT("/metric-thresholds", h.Admin.Ops.UpdateMetricThresholds)
 		}
 
-		// WebSocket realtime (QPS/TPS)
+		// WebSocket realtime (QPS/TPS, error logs)
 		ws := ops.Group("/ws")
 		{
 			ws.GET("/qps", h.Admin.Ops.QPSWSHandler)
+			ws.GET("/errors", h.Admin.Ops.ErrorLogWSHandler)
 		}
 
 		// Error logs (legacy)
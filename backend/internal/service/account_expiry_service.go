@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"log"
 	"sync"
 	"time"
 )
@@ -62,10 +61,10 @@ func (s *AccountExpiryService) runOnce() {
 
 	updated, err := s.accountRepo.AutoPauseExpiredAccounts(ctx, time.Now())
 	if err != nil {
-		log.Printf("[AccountExpiry] Auto pause expired accounts failed: %v", err)
+		logger().Printf("[AccountExpiry] Auto pause expired accounts failed: %v", err)
 		return
 	}
 	if updated > 0 {
-		log.Printf("[AccountExpiry] Auto paused %d expired accounts", updated)
+		logger().Printf("[AccountExpiry] Auto paused %d expired accounts", updated)
 	}
 }
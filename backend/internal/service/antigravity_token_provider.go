@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"errors"
-	"log"
 	"strconv"
 	"strings"
 	"time"
@@ -89,7 +88,7 @@ func (p *AntigravityTokenProvider) GetAccessToken(ctx context.Context, account *
 				}
 				account.Credentials = newCredentials
 				if updateErr := p.accountRepo.Update(ctx, account); updateErr != nil {
-					log.Printf("[AntigravityTokenProvider] Failed to update account credentials: %v", updateErr)
+					logger().Printf("[AntigravityTokenProvider] Failed to update account credentials: %v", updateErr)
 				}
 				expiresAt = account.GetCredentialAsTime("expires_at")
 			}
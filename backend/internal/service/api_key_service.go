@@ -22,12 +22,13 @@ var (
 	ErrAPIKeyExists       = infraerrors.Conflict("API_KEY_EXISTS", "api key already exists")
 	ErrAPIKeyTooShort     = infraerrors.BadRequest("API_KEY_TOO_SHORT", "api key must be at least 16 characters")
 	ErrAPIKeyInvalidChars = infraerrors.BadRequest("API_KEY_INVALID_CHARS", "api key can only contain letters, numbers, underscores, and hyphens")
-	ErrAPIKeyRateLimited  = infraerrors.TooManyRequests("API_KEY_RATE_LIMITED", "too many failed attempts, please try again later")
+	ErrAPIKeyRateLimited  = infraerrors.TooManyRequests("API_KEY_RATE_LIMITED", "too many custom key attempts, please try again later")
 	ErrInvalidIPPattern   = infraerrors.BadRequest("INVALID_IP_PATTERN", "invalid IP or CIDR pattern")
 )
 
 const (
-	apiKeyMaxErrorsPerHour = 20
+	// apiKeyMaxCreateAttemptsPerDay 24h 滑动窗口内允许的自定义Key创建尝试次数
+	apiKeyMaxCreateAttemptsPerDay = 20
 )
 
 type APIKeyRepository interface {
@@ -55,9 +56,14 @@ type APIKeyRepository interface {
 
 // APIKeyCache defines cache operations for API key service
 type APIKeyCache interface {
+	// GetCreateAttemptCount / IncrementCreateAttemptCount 为旧的固定窗口计数，仅为兼容保留；
+	// 创建流程已改用 CheckAndIncrementCreate
 	GetCreateAttemptCount(ctx context.Context, userID int64) (int, error)
 	IncrementCreateAttemptCount(ctx context.Context, userID int64) error
 	DeleteCreateAttemptCount(ctx context.Context, userID int64) error
+	// CheckAndIncrementCreate 基于 24h 滑动窗口检查并记录一次创建尝试；
+	// 返回是否允许、剩余次数，以及窗口内最早一次尝试过期（恢复额度）的时间
+	CheckAndIncrementCreate(ctx context.Context, userID int64, limit int) (allowed bool, remaining int, resetAt time.Time, err error)
 
 	IncrementDailyUsage(ctx context.Context, apiKey string) error
 	SetDailyUsageExpiry(ctx context.Context, apiKey string, ttl time.Duration) error
@@ -165,34 +171,25 @@ func (s *APIKeyService) ValidateCustomKey(key string) error {
 	return nil
 }
 
-// checkAPIKeyRateLimit 检查用户创建自定义Key的错误次数是否超限
-func (s *APIKeyService) checkAPIKeyRateLimit(ctx context.Context, userID int64) error {
+// checkAPIKeyCreateLimit 基于 24h 滑动窗口检查并记录一次自定义Key创建尝试
+func (s *APIKeyService) checkAPIKeyCreateLimit(ctx context.Context, userID int64) error {
 	if s.cache == nil {
 		return nil
 	}
 
-	count, err := s.cache.GetCreateAttemptCount(ctx, userID)
+	allowed, _, _, err := s.cache.CheckAndIncrementCreate(ctx, userID, apiKeyMaxCreateAttemptsPerDay)
 	if err != nil {
 		// Redis 出错时不阻止用户操作
 		return nil
 	}
 
-	if count >= apiKeyMaxErrorsPerHour {
+	if !allowed {
 		return ErrAPIKeyRateLimited
 	}
 
 	return nil
 }
 
-// incrementAPIKeyErrorCount 增加用户创建自定义Key的错误计数
-func (s *APIKeyService) incrementAPIKeyErrorCount(ctx context.Context, userID int64) {
-	if s.cache == nil {
-		return
-	}
-
-	_ = s.cache.IncrementCreateAttemptCount(ctx, userID)
-}
-
 // canUserBindGroup 检查用户是否可以绑定指定分组
 // 对于订阅类型分组：检查用户是否有有效订阅
 // 对于标准类型分组：使用原有的 AllowedGroups 和 IsExclusive 逻辑
@@ -245,8 +242,8 @@ func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIK
 
 	// 判断是否使用自定义Key
 	if req.CustomKey != nil && *req.CustomKey != "" {
-		// 检查限流（仅对自定义key进行限流）
-		if err := s.checkAPIKeyRateLimit(ctx, userID); err != nil {
+		// 检查限流（仅对自定义key进行限流，每次尝试都计入窗口）
+		if err := s.checkAPIKeyCreateLimit(ctx, userID); err != nil {
 			return nil, err
 		}
 
@@ -261,8 +258,6 @@ func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIK
 			return nil, fmt.Errorf("check key exists: %w", err)
 		}
 		if exists {
-			// Key已存在，增加错误计数
-			s.incrementAPIKeyErrorCount(ctx, userID)
 			return nil, ErrAPIKeyExists
 		}
 
@@ -117,6 +117,10 @@ func (s *authCacheStub) DeleteCreateAttemptCount(ctx context.Context, userID int
 	return nil
 }
 
+func (s *authCacheStub) CheckAndIncrementCreate(ctx context.Context, userID int64, limit int) (bool, int, time.Time, error) {
+	return true, limit, time.Time{}, nil
+}
+
 func (s *authCacheStub) IncrementDailyUsage(ctx context.Context, apiKey string) error {
 	return nil
 }
@@ -0,0 +1,39 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createLimitCacheStub 在 apiKeyCacheStub 基础上记录滑动窗口调用并返回预设结果
+type createLimitCacheStub struct {
+	apiKeyCacheStub
+	allowed bool
+	calls   []int64
+}
+
+func (s *createLimitCacheStub) CheckAndIncrementCreate(ctx context.Context, userID int64, limit int) (bool, int, time.Time, error) {
+	s.calls = append(s.calls, userID)
+	return s.allowed, 0, time.Now().Add(time.Hour), nil
+}
+
+// TestApiKeyService_Create_CustomKeyRateLimited 测试自定义Key超出滑动窗口限额时直接拒绝，
+// 不再查询 Key 是否存在。
+func TestApiKeyService_Create_CustomKeyRateLimited(t *testing.T) {
+	cache := &createLimitCacheStub{allowed: false}
+	svc := &APIKeyService{
+		apiKeyRepo: &apiKeyRepoStub{},
+		userRepo:   &userRepoStub{user: &User{ID: 7}},
+		cache:      cache,
+	}
+
+	customKey := "sk-custom-key-0123456789"
+	_, err := svc.Create(context.Background(), 7, CreateAPIKeyRequest{Name: "k", CustomKey: &customKey})
+	require.ErrorIs(t, err, ErrAPIKeyRateLimited)
+	require.Equal(t, []int64{7}, cache.calls)
+}
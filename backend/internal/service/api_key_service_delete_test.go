@@ -145,6 +145,11 @@ func (s *apiKeyCacheStub) DeleteCreateAttemptCount(ctx context.Context, userID i
 	return nil
 }
 
+// CheckAndIncrementCreate 空实现，本测试不验证此行为
+func (s *apiKeyCacheStub) CheckAndIncrementCreate(ctx context.Context, userID int64, limit int) (bool, int, time.Time, error) {
+	return true, limit, time.Time{}, nil
+}
+
 // IncrementDailyUsage 空实现，本测试不验证此行为
 func (s *apiKeyCacheStub) IncrementDailyUsage(ctx context.Context, apiKey string) error {
 	return nil
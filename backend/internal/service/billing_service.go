@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"log"
 	"strings"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
@@ -185,7 +184,7 @@ func (s *BillingService) GetModelPricing(model string) (*ModelPricing, error) {
 	// 2. 使用硬编码回退价格
 	fallback := s.getFallbackPricing(model)
 	if fallback != nil {
-		log.Printf("[Billing] Using fallback pricing for model: %s", model)
+		logger().Printf("[Billing] Using fallback pricing for model: %s", model)
 		return fallback, nil
 	}
 
@@ -4,8 +4,10 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
-	"log"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -14,8 +16,13 @@ import (
 type ConcurrencyCache interface {
 	// 账号槽位管理
 	// 键格式: concurrency:account:{accountID}（有序集合，成员为 requestID）
-	AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error)
+	// AcquireAccountSlot 成功时 current 为占用后的槽位数（含本次）
+	AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (acquired bool, current int, err error)
 	ReleaseAccountSlot(ctx context.Context, accountID int64, requestID string) error
+	// ReleaseAccountSlots 批量释放多个账号槽位（单次网络往返），返回第一个错误但会尝试全部释放
+	ReleaseAccountSlots(ctx context.Context, releases []SlotRelease) error
+	// RenewAccountSlot 为长时间运行的请求续期槽位，槽位已过期时返回 ErrConcurrencySlotExpired
+	RenewAccountSlot(ctx context.Context, accountID int64, requestID string) error
 	GetAccountConcurrency(ctx context.Context, accountID int64) (int, error)
 
 	// 账号等待队列（账号级）
@@ -27,11 +34,27 @@ type ConcurrencyCache interface {
 	// 键格式: concurrency:user:{userID}（有序集合，成员为 requestID）
 	AcquireUserSlot(ctx context.Context, userID int64, maxConcurrency int, requestID string) (bool, error)
 	ReleaseUserSlot(ctx context.Context, userID int64, requestID string) error
+	RenewUserSlot(ctx context.Context, userID int64, requestID string) error
 	GetUserConcurrency(ctx context.Context, userID int64) (int, error)
 
+	// 分组槽位管理（分组内账号共享的并发预算）
+	// 键格式: concurrency:group:{groupID}（有序集合，成员为 requestID）
+	AcquireGroupSlot(ctx context.Context, groupID int64, maxConcurrency int, requestID string) (bool, error)
+	ReleaseGroupSlot(ctx context.Context, groupID int64, requestID string) error
+	RenewGroupSlot(ctx context.Context, groupID int64, requestID string) error
+	GetGroupConcurrency(ctx context.Context, groupID int64) (int, error)
+
 	// 等待队列计数（只在首次创建时设置 TTL）
 	IncrementWaitCount(ctx context.Context, userID int64, maxWait int) (bool, error)
 	DecrementWaitCount(ctx context.Context, userID int64) error
+	GetUserWaitCount(ctx context.Context, userID int64) (int, error)
+
+	// 滑动窗口限流（按任意键，如 API Key）
+	// 键格式: ratelimit:{key}
+	AllowRate(ctx context.Context, key string, limit int, windowSeconds int) (allowed bool, retryAfter time.Duration, err error)
+
+	// 全量账号并发（SCAN concurrency:account:*，清理过期槽位后计数）
+	GetAllAccountConcurrency(ctx context.Context) (map[int64]int, error)
 
 	// 批量负载查询（只读）
 	GetAccountsLoadBatch(ctx context.Context, accounts []AccountWithConcurrency) (map[int64]*AccountLoadInfo, error)
@@ -40,6 +63,9 @@ type ConcurrencyCache interface {
 	CleanupExpiredAccountSlots(ctx context.Context, accountID int64) error
 }
 
+// ErrConcurrencySlotExpired 槽位已过期被清理（或已释放），无法续期
+var ErrConcurrencySlotExpired = errors.New("concurrency slot expired")
+
 // generateRequestID generates a unique request ID for concurrency slot tracking
 // Uses 8 random bytes (16 hex chars) for uniqueness
 func generateRequestID() string {
@@ -54,11 +80,19 @@ func generateRequestID() string {
 const (
 	// Default extra wait slots beyond concurrency limit
 	defaultExtraWaitSlots = 20
+
+	// softLimitLogInterval 同一账号软上限预警日志的最小间隔
+	softLimitLogInterval = time.Minute
 )
 
 // ConcurrencyService manages concurrent request limiting for accounts and users
 type ConcurrencyService struct {
 	cache ConcurrencyCache
+
+	// 软上限预警（0 表示关闭）
+	softLimitPercent   int
+	softLimitCrossings atomic.Int64
+	softLimitLoggedAt  sync.Map // accountID -> time.Time
 }
 
 // NewConcurrencyService creates a new ConcurrencyService
@@ -70,6 +104,18 @@ func NewConcurrencyService(cache ConcurrencyCache) *ConcurrencyService {
 type AcquireResult struct {
 	Acquired    bool
 	ReleaseFunc func() // Must be called when done (typically via defer)
+	// RenewFunc refreshes the slot lease; long-running (streaming) requests should call it
+	// periodically so the slot does not expire mid-request. Returns ErrConcurrencySlotExpired
+	// if the slot was already evicted. Nil when the slot kind does not support renewal.
+	RenewFunc func(ctx context.Context) error
+}
+
+func noopRenew(context.Context) error { return nil }
+
+// SlotRelease identifies one account slot to release in a batch.
+type SlotRelease struct {
+	AccountID int64
+	RequestID string
 }
 
 type AccountWithConcurrency struct {
@@ -93,27 +139,32 @@ func (s *ConcurrencyService) AcquireAccountSlot(ctx context.Context, accountID i
 		return &AcquireResult{
 			Acquired:    true,
 			ReleaseFunc: func() {}, // no-op
+			RenewFunc:   noopRenew,
 		}, nil
 	}
 
 	// Generate unique request ID for this slot
 	requestID := generateRequestID()
 
-	acquired, err := s.cache.AcquireAccountSlot(ctx, accountID, maxConcurrency, requestID)
+	acquired, current, err := s.cache.AcquireAccountSlot(ctx, accountID, maxConcurrency, requestID)
 	if err != nil {
 		return nil, err
 	}
 
 	if acquired {
+		s.checkAccountSoftLimit(accountID, current, maxConcurrency)
 		return &AcquireResult{
 			Acquired: true,
 			ReleaseFunc: func() {
 				bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 				defer cancel()
 				if err := s.cache.ReleaseAccountSlot(bgCtx, accountID, requestID); err != nil {
-					log.Printf("Warning: failed to release account slot for %d (req=%s): %v", accountID, requestID, err)
+					logger().Printf("Warning: failed to release account slot for %d (req=%s): %v", accountID, requestID, err)
 				}
 			},
+			RenewFunc: func(ctx context.Context) error {
+				return s.cache.RenewAccountSlot(ctx, accountID, requestID)
+			},
 		}, nil
 	}
 
@@ -123,6 +174,50 @@ func (s *ConcurrencyService) AcquireAccountSlot(ctx context.Context, accountID i
 	}, nil
 }
 
+// SetSoftLimitPercent sets the soft concurrency threshold as a percentage of an account's
+// max concurrency. 0 (or out of range) disables the check.
+func (s *ConcurrencyService) SetSoftLimitPercent(percent int) {
+	if percent < 0 || percent > 100 {
+		percent = 0
+	}
+	s.softLimitPercent = percent
+}
+
+// SoftLimitCrossings returns how many times an account slot acquisition on this instance
+// brought the account up to the soft threshold since startup. The counter is in-memory and
+// per instance; it is not aggregated across instances and resets on restart.
+func (s *ConcurrencyService) SoftLimitCrossings() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.softLimitCrossings.Load()
+}
+
+// checkAccountSoftLimit records a crossing (and a rate-limited warning) when an acquired
+// slot brings the account's post-acquire count exactly to the soft threshold. Acquisitions
+// that land above the threshold do not count again until the account drops back below it.
+// Best-effort: it never affects the acquire result.
+func (s *ConcurrencyService) checkAccountSoftLimit(accountID int64, current, maxConcurrency int) {
+	if s.softLimitPercent <= 0 || maxConcurrency <= 0 {
+		return
+	}
+	threshold := (maxConcurrency*s.softLimitPercent + 99) / 100
+	if threshold <= 0 {
+		threshold = 1
+	}
+	if current != threshold {
+		return
+	}
+	s.softLimitCrossings.Add(1)
+
+	now := time.Now()
+	if last, ok := s.softLimitLoggedAt.Load(accountID); ok && now.Sub(last.(time.Time)) < softLimitLogInterval {
+		return
+	}
+	s.softLimitLoggedAt.Store(accountID, now)
+	logger().Printf("Warning: account %d concurrency %d/%d reached soft limit (%d%%)", accountID, current, maxConcurrency, s.softLimitPercent)
+}
+
 // AcquireUserSlot attempts to acquire a concurrency slot for a user.
 // If the user is at max concurrency, it waits until a slot is available or timeout.
 // Returns a release function that MUST be called when the request completes.
@@ -132,6 +227,7 @@ func (s *ConcurrencyService) AcquireUserSlot(ctx context.Context, userID int64,
 		return &AcquireResult{
 			Acquired:    true,
 			ReleaseFunc: func() {}, // no-op
+			RenewFunc:   noopRenew,
 		}, nil
 	}
 
@@ -150,9 +246,58 @@ func (s *ConcurrencyService) AcquireUserSlot(ctx context.Context, userID int64,
 				bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 				defer cancel()
 				if err := s.cache.ReleaseUserSlot(bgCtx, userID, requestID); err != nil {
-					log.Printf("Warning: failed to release user slot for %d (req=%s): %v", userID, requestID, err)
+					logger().Printf("Warning: failed to release user slot for %d (req=%s): %v", userID, requestID, err)
+				}
+			},
+			RenewFunc: func(ctx context.Context) error {
+				return s.cache.RenewUserSlot(ctx, userID, requestID)
+			},
+		}, nil
+	}
+
+	return &AcquireResult{
+		Acquired:    false,
+		ReleaseFunc: nil,
+	}, nil
+}
+
+// ============================================
+// Group Slot Methods
+// ============================================
+
+// AcquireGroupSlot attempts to acquire a slot from a group's pooled concurrency budget.
+// Callers acquire account -> group -> user slots in order and release them in reverse on failure.
+// Returns a release function that MUST be called when the request completes.
+func (s *ConcurrencyService) AcquireGroupSlot(ctx context.Context, groupID int64, maxConcurrency int) (*AcquireResult, error) {
+	// If maxConcurrency is 0 or negative, no limit
+	if maxConcurrency <= 0 {
+		return &AcquireResult{
+			Acquired:    true,
+			ReleaseFunc: func() {}, // no-op
+			RenewFunc:   noopRenew,
+		}, nil
+	}
+
+	requestID := generateRequestID()
+
+	acquired, err := s.cache.AcquireGroupSlot(ctx, groupID, maxConcurrency, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if acquired {
+		return &AcquireResult{
+			Acquired: true,
+			ReleaseFunc: func() {
+				bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := s.cache.ReleaseGroupSlot(bgCtx, groupID, requestID); err != nil {
+					logger().Printf("Warning: failed to release group slot for %d (req=%s): %v", groupID, requestID, err)
 				}
 			},
+			RenewFunc: func(ctx context.Context) error {
+				return s.cache.RenewGroupSlot(ctx, groupID, requestID)
+			},
 		}, nil
 	}
 
@@ -162,6 +307,14 @@ func (s *ConcurrencyService) AcquireUserSlot(ctx context.Context, userID int64,
 	}, nil
 }
 
+// GetGroupConcurrency returns the number of in-flight requests holding a group slot.
+func (s *ConcurrencyService) GetGroupConcurrency(ctx context.Context, groupID int64) (int, error) {
+	if s.cache == nil {
+		return 0, nil
+	}
+	return s.cache.GetGroupConcurrency(ctx, groupID)
+}
+
 // ============================================
 // Wait Queue Count Methods
 // ============================================
@@ -178,7 +331,7 @@ func (s *ConcurrencyService) IncrementWaitCount(ctx context.Context, userID int6
 	result, err := s.cache.IncrementWaitCount(ctx, userID, maxWait)
 	if err != nil {
 		// On error, allow the request to proceed (fail open)
-		log.Printf("Warning: increment wait count failed for user %d: %v", userID, err)
+		logger().Printf("Warning: increment wait count failed for user %d: %v", userID, err)
 		return true, nil
 	}
 	return result, nil
@@ -196,10 +349,19 @@ func (s *ConcurrencyService) DecrementWaitCount(ctx context.Context, userID int6
 	defer cancel()
 
 	if err := s.cache.DecrementWaitCount(bgCtx, userID); err != nil {
-		log.Printf("Warning: decrement wait count failed for user %d: %v", userID, err)
+		logger().Printf("Warning: decrement wait count failed for user %d: %v", userID, err)
 	}
 }
 
+// GetUserWaitCount gets the current wait queue count for a user.
+// Right after a successful IncrementWaitCount it is the caller's position in that user's queue.
+func (s *ConcurrencyService) GetUserWaitCount(ctx context.Context, userID int64) (int, error) {
+	if s.cache == nil {
+		return 0, nil
+	}
+	return s.cache.GetUserWaitCount(ctx, userID)
+}
+
 // IncrementAccountWaitCount increments the wait queue counter for an account.
 func (s *ConcurrencyService) IncrementAccountWaitCount(ctx context.Context, accountID int64, maxWait int) (bool, error) {
 	if s.cache == nil {
@@ -208,7 +370,7 @@ func (s *ConcurrencyService) IncrementAccountWaitCount(ctx context.Context, acco
 
 	result, err := s.cache.IncrementAccountWaitCount(ctx, accountID, maxWait)
 	if err != nil {
-		log.Printf("Warning: increment wait count failed for account %d: %v", accountID, err)
+		logger().Printf("Warning: increment wait count failed for account %d: %v", accountID, err)
 		return true, nil
 	}
 	return result, nil
@@ -224,7 +386,7 @@ func (s *ConcurrencyService) DecrementAccountWaitCount(ctx context.Context, acco
 	defer cancel()
 
 	if err := s.cache.DecrementAccountWaitCount(bgCtx, accountID); err != nil {
-		log.Printf("Warning: decrement wait count failed for account %d: %v", accountID, err)
+		logger().Printf("Warning: decrement wait count failed for account %d: %v", accountID, err)
 	}
 }
 
@@ -236,6 +398,36 @@ func (s *ConcurrencyService) GetAccountWaitingCount(ctx context.Context, account
 	return s.cache.GetAccountWaitingCount(ctx, accountID)
 }
 
+// AllowRate checks a sliding-window request limit (limit requests per windowSeconds) for key.
+// Fails open when Redis is unavailable, like the wait queue counters.
+func (s *ConcurrencyService) AllowRate(ctx context.Context, key string, limit int, windowSeconds int) (bool, time.Duration) {
+	if s.cache == nil || limit <= 0 || windowSeconds <= 0 {
+		return true, 0
+	}
+	allowed, retryAfter, err := s.cache.AllowRate(ctx, key, limit, windowSeconds)
+	if err != nil {
+		logger().Printf("Warning: rate limit check failed for %s: %v", key, err)
+		return true, 0
+	}
+	return allowed, retryAfter
+}
+
+// GetInFlightRequests returns the total number of account slots currently held across all accounts.
+func (s *ConcurrencyService) GetInFlightRequests(ctx context.Context) (int, error) {
+	if s.cache == nil {
+		return 0, nil
+	}
+	perAccount, err := s.cache.GetAllAccountConcurrency(ctx)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, n := range perAccount {
+		total += n
+	}
+	return total, nil
+}
+
 // CalculateMaxWait calculates the maximum wait queue size for a user
 // maxWait = userConcurrency + defaultExtraWaitSlots
 func CalculateMaxWait(userConcurrency int) int {
@@ -272,7 +464,7 @@ func (s *ConcurrencyService) StartSlotCleanupWorker(accountRepo AccountRepositor
 		accounts, err := accountRepo.ListSchedulable(listCtx)
 		cancel()
 		if err != nil {
-			log.Printf("Warning: list schedulable accounts failed: %v", err)
+			logger().Printf("Warning: list schedulable accounts failed: %v", err)
 			return
 		}
 		for _, account := range accounts {
@@ -280,7 +472,7 @@ func (s *ConcurrencyService) StartSlotCleanupWorker(accountRepo AccountRepositor
 			err := s.cache.CleanupExpiredAccountSlots(accountCtx, account.ID)
 			accountCancel()
 			if err != nil {
-				log.Printf("Warning: cleanup expired slots failed for account %d: %v", account.ID, err)
+				logger().Printf("Warning: cleanup expired slots failed for account %d: %v", account.ID, err)
 			}
 		}
 	}
@@ -0,0 +1,122 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type softLimitConcurrencyCache struct {
+	ConcurrencyCache
+	current int
+}
+
+func (c *softLimitConcurrencyCache) AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, int, error) {
+	return true, c.current, nil
+}
+
+func (c *softLimitConcurrencyCache) ReleaseAccountSlot(ctx context.Context, accountID int64, requestID string) error {
+	return nil
+}
+
+func TestAcquireAccountSlot_SoftLimitCrossing(t *testing.T) {
+	cache := &softLimitConcurrencyCache{current: 3}
+	svc := NewConcurrencyService(cache)
+	svc.SetSoftLimitPercent(80) // max 5 -> threshold 4
+
+	res, err := svc.AcquireAccountSlot(context.Background(), 1, 5)
+	require.NoError(t, err)
+	require.True(t, res.Acquired)
+	require.Zero(t, svc.SoftLimitCrossings())
+
+	cache.current = 4
+	res, err = svc.AcquireAccountSlot(context.Background(), 1, 5)
+	require.NoError(t, err)
+	require.True(t, res.Acquired, "soft limit must not reject the request")
+	require.Equal(t, int64(1), svc.SoftLimitCrossings())
+
+	// 已在阈值之上的后续占用不重复计数
+	cache.current = 5
+	_, err = svc.AcquireAccountSlot(context.Background(), 1, 5)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), svc.SoftLimitCrossings())
+
+	// 回落到阈值以下后再次达到阈值，计为新的一次越过
+	cache.current = 4
+	_, err = svc.AcquireAccountSlot(context.Background(), 1, 5)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), svc.SoftLimitCrossings())
+}
+
+func TestAcquireAccountSlot_SoftLimitDisabled(t *testing.T) {
+	cache := &softLimitConcurrencyCache{current: 5}
+	svc := NewConcurrencyService(cache)
+
+	_, err := svc.AcquireAccountSlot(context.Background(), 1, 5)
+	require.NoError(t, err)
+	require.Zero(t, svc.SoftLimitCrossings())
+}
+
+type rateLimitConcurrencyCache struct {
+	ConcurrencyCache
+	allowed    bool
+	retryAfter time.Duration
+	err        error
+}
+
+func (c *rateLimitConcurrencyCache) AllowRate(ctx context.Context, key string, limit int, windowSeconds int) (bool, time.Duration, error) {
+	return c.allowed, c.retryAfter, c.err
+}
+
+func TestConcurrencyService_AllowRate(t *testing.T) {
+	svc := NewConcurrencyService(&rateLimitConcurrencyCache{allowed: false, retryAfter: 3 * time.Second})
+	allowed, retryAfter := svc.AllowRate(context.Background(), "apikey:1", 10, 60)
+	require.False(t, allowed)
+	require.Equal(t, 3*time.Second, retryAfter)
+
+	// Redis 故障时放行（fail open）
+	svc = NewConcurrencyService(&rateLimitConcurrencyCache{err: errors.New("redis down")})
+	allowed, retryAfter = svc.AllowRate(context.Background(), "apikey:1", 10, 60)
+	require.True(t, allowed)
+	require.Zero(t, retryAfter)
+
+	// 未配置限额时不访问缓存
+	svc = NewConcurrencyService(&rateLimitConcurrencyCache{allowed: false})
+	allowed, _ = svc.AllowRate(context.Background(), "apikey:1", 0, 60)
+	require.True(t, allowed)
+}
+
+type groupSlotConcurrencyCache struct {
+	ConcurrencyCache
+	renewedGroupID   int64
+	renewedRequestID string
+}
+
+func (c *groupSlotConcurrencyCache) AcquireGroupSlot(ctx context.Context, groupID int64, maxConcurrency int, requestID string) (bool, error) {
+	return true, nil
+}
+
+func (c *groupSlotConcurrencyCache) RenewGroupSlot(ctx context.Context, groupID int64, requestID string) error {
+	c.renewedGroupID = groupID
+	c.renewedRequestID = requestID
+	return nil
+}
+
+func TestAcquireGroupSlot_RenewFunc(t *testing.T) {
+	cache := &groupSlotConcurrencyCache{}
+	svc := NewConcurrencyService(cache)
+
+	res, err := svc.AcquireGroupSlot(context.Background(), 7, 2)
+	require.NoError(t, err)
+	require.True(t, res.Acquired)
+	require.NotNil(t, res.RenewFunc)
+
+	require.NoError(t, res.RenewFunc(context.Background()))
+	require.Equal(t, int64(7), cache.renewedGroupID)
+	require.NotEmpty(t, cache.renewedRequestID)
+}
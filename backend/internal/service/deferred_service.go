@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"log"
 	"sync"
 	"time"
 )
@@ -28,14 +27,14 @@ func NewDeferredService(accountRepo AccountRepository, timingWheel *TimingWheelS
 // Start starts the deferred service
 func (s *DeferredService) Start() {
 	s.timingWheel.ScheduleRecurring("deferred:last_used", s.interval, s.flushLastUsed)
-	log.Printf("[DeferredService] Started (interval: %v)", s.interval)
+	logger().Printf("[DeferredService] Started (interval: %v)", s.interval)
 }
 
 // Stop stops the deferred service
 func (s *DeferredService) Stop() {
 	s.timingWheel.Cancel("deferred:last_used")
 	s.flushLastUsed()
-	log.Printf("[DeferredService] Service stopped")
+	logger().Printf("[DeferredService] Service stopped")
 }
 
 func (s *DeferredService) ScheduleLastUsedUpdate(accountID int64) {
@@ -66,11 +65,11 @@ func (s *DeferredService) flushLastUsed() {
 	defer cancel()
 
 	if err := s.accountRepo.BatchUpdateLastUsed(ctx, updates); err != nil {
-		log.Printf("[DeferredService] BatchUpdateLastUsed failed (%d accounts): %v", len(updates), err)
+		logger().Printf("[DeferredService] BatchUpdateLastUsed failed (%d accounts): %v", len(updates), err)
 		for id, ts := range updates {
 			s.lastUsedUpdates.Store(id, ts)
 		}
 	} else {
-		log.Printf("[DeferredService] BatchUpdateLastUsed flushed %d accounts", len(updates))
+		logger().Printf("[DeferredService] BatchUpdateLastUsed flushed %d accounts", len(updates))
 	}
 }
@@ -1,5 +1,7 @@
 package service
 
+import "strings"
+
 // Status constants
 const (
 	StatusActive   = "active"
@@ -24,6 +26,52 @@ const (
 	PlatformAntigravity = "antigravity"
 )
 
+// Platform 规范化后的平台标识，取值为 Platform* 常量之一。
+// Platform* 常量保持无类型，既能与已有的 string 字段比较，也能直接作为 Platform 使用。
+type Platform string
+
+// platformAliases 历史数据 / 外部调用中出现过的平台别名 -> 规范平台值
+// （ops_error_logs 中的历史值由迁移 045 统一改写，新增别名时需同步迁移）
+var platformAliases = map[string]Platform{
+	"claude":      PlatformAnthropic,
+	"ai_studio":   PlatformGemini,
+	"aistudio":    PlatformGemini,
+	"code_assist": PlatformGemini,
+	"google_one":  PlatformGemini,
+}
+
+// ParsePlatform 将平台字符串解析为 Platform。
+// 大小写与首尾空白不敏感，并兼容 platformAliases 中的历史别名；无法识别时 ok=false。
+func ParsePlatform(raw string) (platform Platform, ok bool) {
+	v := Platform(strings.ToLower(strings.TrimSpace(raw)))
+	if v.IsValid() {
+		return v, true
+	}
+	if mapped, exists := platformAliases[string(v)]; exists {
+		return mapped, true
+	}
+	return "", false
+}
+
+// IsValid 是否为规范平台值（不接受别名）
+func (p Platform) IsValid() bool {
+	switch p {
+	case PlatformAnthropic, PlatformOpenAI, PlatformGemini, PlatformAntigravity:
+		return true
+	default:
+		return false
+	}
+}
+
+// NormalizePlatform 尽量将平台字符串规范化；无法识别时返回去除首尾空白后的原值，
+// 用于写入路径（不因未知平台丢弃数据）。
+func NormalizePlatform(raw string) Platform {
+	if platform, ok := ParsePlatform(raw); ok {
+		return platform
+	}
+	return Platform(strings.TrimSpace(raw))
+}
+
 // Account type constants
 const (
 	AccountTypeOAuth      = "oauth"       // OAuth类型账号（full scope: profile + inference）
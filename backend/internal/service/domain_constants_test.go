@@ -0,0 +1,45 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlatform(t *testing.T) {
+	cases := []struct {
+		in   string
+		want Platform
+		ok   bool
+	}{
+		{"gemini", PlatformGemini, true},
+		{" Gemini ", PlatformGemini, true},
+		{"ai_studio", PlatformGemini, true},
+		{"code_assist", PlatformGemini, true},
+		{"google_one", PlatformGemini, true},
+		{"claude", PlatformAnthropic, true},
+		{"OPENAI", PlatformOpenAI, true},
+		{"antigravity", PlatformAntigravity, true},
+		{"", "", false},
+		{"unknown", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := ParsePlatform(tc.in)
+		require.Equal(t, tc.ok, ok, tc.in)
+		require.Equal(t, tc.want, got, tc.in)
+	}
+}
+
+func TestPlatformIsValid(t *testing.T) {
+	require.True(t, Platform(PlatformAntigravity).IsValid())
+	require.False(t, Platform("ai_studio").IsValid())
+	require.False(t, Platform("").IsValid())
+}
+
+func TestNormalizePlatform_KeepsUnknownValues(t *testing.T) {
+	require.Equal(t, Platform(PlatformGemini), NormalizePlatform("AI_Studio"))
+	require.Equal(t, Platform("custom"), NormalizePlatform(" custom "))
+	require.Equal(t, Platform(""), NormalizePlatform(""))
+}
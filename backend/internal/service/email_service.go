@@ -5,7 +5,6 @@ import (
 	"crypto/rand"
 	"crypto/tls"
 	"fmt"
-	"log"
 	"math/big"
 	"net/smtp"
 	"strconv"
@@ -258,7 +257,7 @@ func (s *EmailService) VerifyCode(ctx context.Context, email, code string) error
 	if data.Code != code {
 		data.Attempts++
 		if err := s.cache.SetVerificationCode(ctx, email, data, verifyCodeTTL); err != nil {
-			log.Printf("[Email] Failed to update verification attempt count: %v", err)
+			logger().Printf("[Email] Failed to update verification attempt count: %v", err)
 		}
 		if data.Attempts >= maxVerifyCodeAttempts {
 			return ErrVerifyCodeMaxAttempts
@@ -268,7 +267,7 @@ func (s *EmailService) VerifyCode(ctx context.Context, email, code string) error
 
 	// 验证成功，删除验证码
 	if err := s.cache.DeleteVerificationCode(ctx, email); err != nil {
-		log.Printf("[Email] Failed to delete verification code after success: %v", err)
+		logger().Printf("[Email] Failed to delete verification code after success: %v", err)
 	}
 	return nil
 }
@@ -961,15 +961,23 @@ type mockConcurrencyCache struct {
 	loadBatchCalls      int
 }
 
-func (m *mockConcurrencyCache) AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error) {
+func (m *mockConcurrencyCache) AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, int, error) {
 	m.acquireAccountCalls++
-	return true, nil
+	return true, 1, nil
 }
 
 func (m *mockConcurrencyCache) ReleaseAccountSlot(ctx context.Context, accountID int64, requestID string) error {
 	return nil
 }
 
+func (m *mockConcurrencyCache) ReleaseAccountSlots(ctx context.Context, releases []SlotRelease) error {
+	return nil
+}
+
+func (m *mockConcurrencyCache) RenewAccountSlot(ctx context.Context, accountID int64, requestID string) error {
+	return nil
+}
+
 func (m *mockConcurrencyCache) GetAccountConcurrency(ctx context.Context, accountID int64) (int, error) {
 	return 0, nil
 }
@@ -994,10 +1002,30 @@ func (m *mockConcurrencyCache) ReleaseUserSlot(ctx context.Context, userID int64
 	return nil
 }
 
+func (m *mockConcurrencyCache) RenewUserSlot(ctx context.Context, userID int64, requestID string) error {
+	return nil
+}
+
 func (m *mockConcurrencyCache) GetUserConcurrency(ctx context.Context, userID int64) (int, error) {
 	return 0, nil
 }
 
+func (m *mockConcurrencyCache) AcquireGroupSlot(ctx context.Context, groupID int64, maxConcurrency int, requestID string) (bool, error) {
+	return true, nil
+}
+
+func (m *mockConcurrencyCache) ReleaseGroupSlot(ctx context.Context, groupID int64, requestID string) error {
+	return nil
+}
+
+func (m *mockConcurrencyCache) RenewGroupSlot(ctx context.Context, groupID int64, requestID string) error {
+	return nil
+}
+
+func (m *mockConcurrencyCache) GetGroupConcurrency(ctx context.Context, groupID int64) (int, error) {
+	return 0, nil
+}
+
 func (m *mockConcurrencyCache) IncrementWaitCount(ctx context.Context, userID int64, maxWait int) (bool, error) {
 	return true, nil
 }
@@ -1006,6 +1034,18 @@ func (m *mockConcurrencyCache) DecrementWaitCount(ctx context.Context, userID in
 	return nil
 }
 
+func (m *mockConcurrencyCache) GetUserWaitCount(ctx context.Context, userID int64) (int, error) {
+	return 0, nil
+}
+
+func (m *mockConcurrencyCache) GetAllAccountConcurrency(ctx context.Context) (map[int64]int, error) {
+	return map[int64]int{}, nil
+}
+
+func (m *mockConcurrencyCache) AllowRate(ctx context.Context, key string, limit int, windowSeconds int) (bool, time.Duration, error) {
+	return true, 0, nil
+}
+
 func (m *mockConcurrencyCache) GetAccountsLoadBatch(ctx context.Context, accounts []AccountWithConcurrency) (map[int64]*AccountLoadInfo, error) {
 	m.loadBatchCalls++
 	result := make(map[int64]*AccountLoadInfo, len(accounts))
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
 )
 
 // ParsedRequest 保存网关请求的预解析结果
@@ -19,13 +22,22 @@ import (
 // 2. 将解析结果 ParsedRequest 传递给 Service 层
 // 3. 避免重复 json.Unmarshal，减少 CPU 和内存开销
 type ParsedRequest struct {
-	Body           []byte // 原始请求体（保留用于转发）
-	Model          string // 请求的模型名称
-	Stream         bool   // 是否为流式请求
-	MetadataUserID string // metadata.user_id（用于会话亲和）
-	System         any    // system 字段内容
-	Messages       []any  // messages 数组
-	HasSystem      bool   // 是否包含 system 字段（包含 null 也视为显式传入）
+	Body           []byte        // 原始请求体（保留用于转发）
+	RawByteSize    int           // 原始请求体字节数
+	Model          string        // 请求的模型名称
+	Stream         bool          // 是否为流式请求
+	MetadataUserID string        // metadata.user_id，缺失时取 OpenAI 顶层 user（用于会话亲和）
+	System         any           // system 字段内容
+	Messages       []any         // messages 数组
+	HasSystem      bool          // 是否包含 system 字段（包含 null 也视为显式传入）
+	Tools          []GatewayTool // 请求声明的工具（Claude tools / OpenAI tools、functions）
+	HasTools       bool          // 是否声明了至少一个工具
+}
+
+// GatewayTool 请求中声明的工具，仅保留路由所需的信息
+type GatewayTool struct {
+	Name      string // 工具名称
+	HasSchema bool   // 是否携带输入参数 schema（input_schema / parameters）
 }
 
 // ParseGatewayRequest 解析网关请求体并返回结构化结果
@@ -37,39 +49,237 @@ func ParseGatewayRequest(body []byte) (*ParsedRequest, error) {
 	}
 
 	parsed := &ParsedRequest{
-		Body: body,
+		Body:        body,
+		RawByteSize: len(body),
+	}
+	if err := parseModelAndStream(req, parsed); err != nil {
+		return nil, err
+	}
+
+	parsed.MetadataUserID = parseMetadataUserID(req)
+	// system 字段只要存在就视为显式提供（即使为 null），
+	// 以避免客户端传 null 时被默认 system 误注入。
+	if system, ok := req["system"]; ok {
+		parsed.HasSystem = true
+		parsed.System = system
+	}
+	if messages, ok := req["messages"].([]any); ok {
+		parsed.Messages = messages
+	}
+	if err := parseTools(req, parsed); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+// ParseOpenAIRequest 解析 OpenAI Chat Completions 格式的请求体，填充与 ParseGatewayRequest 相同的结构。
+// OpenAI 格式没有顶层 system 字段：开头的 {"role":"system"} 消息映射为 System/HasSystem，
+// 并从 Messages 中移除，与 Claude 格式保持一致。
+func ParseOpenAIRequest(body []byte) (*ParsedRequest, error) {
+	var req map[string]any
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+
+	parsed := &ParsedRequest{
+		Body:        body,
+		RawByteSize: len(body),
+	}
+	if err := parseModelAndStream(req, parsed); err != nil {
+		return nil, err
+	}
+	if err := parseTools(req, parsed); err != nil {
+		return nil, err
+	}
+	parsed.MetadataUserID = parseMetadataUserID(req)
+
+	messages, ok := req["messages"].([]any)
+	if !ok {
+		return parsed, nil
+	}
+	if len(messages) > 0 {
+		if first, ok := messages[0].(map[string]any); ok && first["role"] == "system" {
+			parsed.HasSystem = true
+			parsed.System = first["content"]
+			messages = messages[1:]
+		}
+	}
+	parsed.Messages = messages
+
+	return parsed, nil
+}
+
+// parseTools 解析顶层 tools（Claude/OpenAI）与 functions（OpenAI 旧格式）
+//   - Claude: {"name":"x","input_schema":{...}}，custom 工具为 {"type":"custom","name":"x","custom":{"input_schema":{...}}}
+//   - OpenAI: {"type":"function","function":{"name":"x","parameters":{...}}}
+//   - OpenAI functions: {"name":"x","parameters":{...}}
+//
+// 字段不是数组、条目不是对象，或 Claude/OpenAI 形态的条目缺少有效 name 时返回错误（null 视为未提供）。
+// 既没有 name 也没有 function 的对象（Gemini functionDeclarations、googleSearch 等内置工具）
+// 不属于上述形态，直接跳过：gemini 入口同样经由 ParseGatewayRequest 解析，请求体原样交给平台转换器处理。
+func parseTools(req map[string]any, parsed *ParsedRequest) error {
+	for _, field := range []string{"tools", "functions"} {
+		raw, exists := req[field]
+		if !exists || raw == nil {
+			continue
+		}
+		entries, ok := raw.([]any)
+		if !ok {
+			return fmt.Errorf("invalid %s field type", field)
+		}
+		for i, entry := range entries {
+			tool, ok, err := parseGatewayTool(entry)
+			if err != nil {
+				return fmt.Errorf("invalid %s[%d]: %w", field, i, err)
+			}
+			if ok {
+				parsed.Tools = append(parsed.Tools, tool)
+			}
+		}
+	}
+	parsed.HasTools = len(parsed.Tools) > 0
+	return nil
+}
+
+// parseGatewayTool 解析单个工具条目；ok=false 表示条目不是 Claude/OpenAI 形态，调用方应跳过
+func parseGatewayTool(entry any) (tool GatewayTool, ok bool, err error) {
+	m, isMap := entry.(map[string]any)
+	if !isMap {
+		return GatewayTool{}, false, fmt.Errorf("entry must be an object")
+	}
+	// OpenAI tools 的定义位于 function 字段中
+	fn, isOpenAI := m["function"]
+	if isOpenAI {
+		if m, isMap = fn.(map[string]any); !isMap {
+			return GatewayTool{}, false, fmt.Errorf("invalid function field type")
+		}
+	} else if m["type"] == "function" {
+		return GatewayTool{}, false, fmt.Errorf("missing function definition")
+	}
+	rawName, exists := m["name"]
+	if !exists {
+		if isOpenAI {
+			return GatewayTool{}, false, fmt.Errorf("missing tool name")
+		}
+		return GatewayTool{}, false, nil
+	}
+	name, isString := rawName.(string)
+	if !isString || name == "" {
+		return GatewayTool{}, false, fmt.Errorf("invalid tool name")
+	}
+	tool = GatewayTool{Name: name}
+	for _, key := range []string{"input_schema", "parameters"} {
+		if _, isMap := m[key].(map[string]any); isMap {
+			tool.HasSchema = true
+		}
+	}
+	if custom, isMap := m["custom"].(map[string]any); isMap {
+		if _, isMap := custom["input_schema"].(map[string]any); isMap {
+			tool.HasSchema = true
+		}
+	}
+	return tool, true, nil
+}
+
+// parseMetadataUserID 提取稳定的用户标识：优先 Claude 的 metadata.user_id，
+// 缺失时回退到 OpenAI 的顶层 user 字段
+func parseMetadataUserID(req map[string]any) string {
+	if metadata, ok := req["metadata"].(map[string]any); ok {
+		if userID, ok := metadata["user_id"].(string); ok && userID != "" {
+			return userID
+		}
+	}
+	if user, ok := req["user"].(string); ok {
+		return user
 	}
+	return ""
+}
 
+// parseModelAndStream 解析 Claude/OpenAI 共有的 model 与 stream 字段
+func parseModelAndStream(req map[string]any, parsed *ParsedRequest) error {
 	if rawModel, exists := req["model"]; exists {
 		model, ok := rawModel.(string)
 		if !ok {
-			return nil, fmt.Errorf("invalid model field type")
+			return fmt.Errorf("invalid model field type")
 		}
 		parsed.Model = model
 	}
 	if rawStream, exists := req["stream"]; exists {
 		stream, ok := rawStream.(bool)
 		if !ok {
-			return nil, fmt.Errorf("invalid stream field type")
+			return fmt.Errorf("invalid stream field type")
 		}
 		parsed.Stream = stream
 	}
-	if metadata, ok := req["metadata"].(map[string]any); ok {
-		if userID, ok := metadata["user_id"].(string); ok {
-			parsed.MetadataUserID = userID
-		}
+	return nil
+}
+
+// EstimateInputTokens 粗略估算输入 token 数：对 system 与 messages 中的文本按约 4 字符 1 token 计算。
+// 结果与具体模型/分词器无关，仅用于转发前的大小限制等近似判断，不能用于计费。
+func (p *ParsedRequest) EstimateInputTokens() int {
+	if p == nil {
+		return 0
 	}
-	// system 字段只要存在就视为显式提供（即使为 null），
-	// 以避免客户端传 null 时被默认 system 误注入。
-	if system, ok := req["system"]; ok {
-		parsed.HasSystem = true
-		parsed.System = system
+	chars := estimateTextChars(p.System)
+	for _, msg := range p.Messages {
+		if m, ok := msg.(map[string]any); ok {
+			chars += estimateTextChars(m["content"])
+		}
 	}
-	if messages, ok := req["messages"].([]any); ok {
-		parsed.Messages = messages
+	return (chars + 3) / 4
+}
+
+// estimateTextChars 统计 content 中的文本字符数（字符串、text 块及 tool_result 嵌套 content）
+func estimateTextChars(value any) int {
+	switch v := value.(type) {
+	case string:
+		return utf8.RuneCountInString(v)
+	case []any:
+		n := 0
+		for _, item := range v {
+			n += estimateTextChars(item)
+		}
+		return n
+	case map[string]any:
+		n := 0
+		if text, ok := v["text"].(string); ok {
+			n += utf8.RuneCountInString(text)
+		}
+		if content, ok := v["content"]; ok {
+			n += estimateTextChars(content)
+		}
+		return n
+	default:
+		return 0
 	}
+}
 
-	return parsed, nil
+// ReconcileStreamAccept 协调请求体 stream 字段与客户端 Accept 头的冲突
+//
+// 客户端可能在请求体中设置 stream:true 却发送 Accept: application/json（或反之）。
+// 网关以请求体 stream 字段为准（它决定超时与响应处理分支），并就地改写 Accept 头，
+// 避免白名单透传后上游按另一种格式响应。Accept 为空、*/* 或同时接受两种格式时不做处理。
+// 返回 true 表示检测到冲突并已改写。
+func ReconcileStreamAccept(header http.Header, stream bool) bool {
+	if header == nil {
+		return false
+	}
+	accept := strings.ToLower(header.Get("Accept"))
+	if accept == "" {
+		return false
+	}
+	wantsSSE := strings.Contains(accept, "text/event-stream")
+	wantsJSON := strings.Contains(accept, "application/json")
+	switch {
+	case stream && wantsJSON && !wantsSSE:
+		header.Set("Accept", "text/event-stream")
+	case !stream && wantsSSE && !wantsJSON:
+		header.Set("Accept", "application/json")
+	default:
+		return false
+	}
+	return true
 }
 
 // FilterThinkingBlocks removes thinking blocks from request body
@@ -81,6 +291,22 @@ func ParseGatewayRequest(body []byte) (*ParsedRequest, error) {
 //   - When thinking.type == "enabled": Only remove thinking blocks without valid signatures
 //     (blocks with missing/empty/dummy signatures that would cause 400 errors)
 func FilterThinkingBlocks(body []byte) []byte {
+	return FilterThinkingBlocksOpt(body, false)
+}
+
+// FilterThinkingBlocksOpt is FilterThinkingBlocks with control over signed blocks.
+// When keepSigned is true, assistant thinking blocks carrying a valid signature are kept
+// even if top-level thinking is not enabled (multi-turn extended thinking must replay them);
+// unsigned blocks are still removed.
+func FilterThinkingBlocksOpt(body []byte, keepSigned bool) []byte {
+	result, _ := filterThinkingBlocksInternal(body, keepSigned)
+	return result
+}
+
+// FilterThinkingBlocksWithCount is FilterThinkingBlocks that also reports how many
+// thinking blocks were removed, so callers can record it without comparing bytes.
+// Invalid JSON returns the original body and 0.
+func FilterThinkingBlocksWithCount(body []byte) ([]byte, int) {
 	return filterThinkingBlocksInternal(body, false)
 }
 
@@ -407,7 +633,8 @@ func FilterSignatureSensitiveBlocksForRetry(body []byte) []byte {
 // Strategy:
 //   - When thinking.type != "enabled": Remove all thinking blocks
 //   - When thinking.type == "enabled": Only remove thinking blocks without valid signatures
-func filterThinkingBlocksInternal(body []byte, _ bool) []byte {
+//   - keepSigned applies the signed-block rule regardless of thinking.type
+func filterThinkingBlocksInternal(body []byte, keepSigned bool) ([]byte, int) {
 	// Fast path: if body doesn't contain "thinking", skip parsing
 	if !bytes.Contains(body, []byte(`"type":"thinking"`)) &&
 		!bytes.Contains(body, []byte(`"type": "thinking"`)) &&
@@ -415,12 +642,12 @@ func filterThinkingBlocksInternal(body []byte, _ bool) []byte {
 		!bytes.Contains(body, []byte(`"type": "redacted_thinking"`)) &&
 		!bytes.Contains(body, []byte(`"thinking":`)) &&
 		!bytes.Contains(body, []byte(`"thinking" :`)) {
-		return body
+		return body, 0
 	}
 
 	var req map[string]any
 	if err := json.Unmarshal(body, &req); err != nil {
-		return body
+		return body, 0
 	}
 
 	// Check if thinking is enabled
@@ -433,10 +660,10 @@ func filterThinkingBlocksInternal(body []byte, _ bool) []byte {
 
 	messages, ok := req["messages"].([]any)
 	if !ok {
-		return body
+		return body, 0
 	}
 
-	filtered := false
+	removed := 0
 	for _, msg := range messages {
 		msgMap, ok := msg.(map[string]any)
 		if !ok {
@@ -462,16 +689,16 @@ func filterThinkingBlocksInternal(body []byte, _ bool) []byte {
 			blockType, _ := blockMap["type"].(string)
 
 			if blockType == "thinking" || blockType == "redacted_thinking" {
-				// When thinking is enabled and this is an assistant message,
-				// only keep thinking blocks with valid signatures
-				if thinkingEnabled && role == "assistant" {
+				// When thinking is enabled (or keepSigned is requested) and this is an
+				// assistant message, only keep thinking blocks with valid signatures
+				if (thinkingEnabled || keepSigned) && role == "assistant" {
 					signature, _ := blockMap["signature"].(string)
 					if signature != "" && signature != "skip_thought_signature_validator" {
 						newContent = append(newContent, block)
 						continue
 					}
 				}
-				filtered = true
+				removed++
 				filteredThisMessage = true
 				continue
 			}
@@ -479,7 +706,7 @@ func filterThinkingBlocksInternal(body []byte, _ bool) []byte {
 			// Handle blocks without type discriminator but with "thinking" key
 			if blockType == "" {
 				if _, hasThinking := blockMap["thinking"]; hasThinking {
-					filtered = true
+					removed++
 					filteredThisMessage = true
 					continue
 				}
@@ -493,13 +720,13 @@ func filterThinkingBlocksInternal(body []byte, _ bool) []byte {
 		}
 	}
 
-	if !filtered {
-		return body
+	if removed == 0 {
+		return body, 0
 	}
 
 	newBody, err := json.Marshal(req)
 	if err != nil {
-		return body
+		return body, 0
 	}
-	return newBody
+	return newBody, removed
 }
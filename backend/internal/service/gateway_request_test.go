@@ -2,6 +2,7 @@ package service
 
 import (
 	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -40,6 +41,176 @@ func TestParseGatewayRequest_InvalidStreamType(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestParseGatewayRequest_MetadataUserID(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "metadata wins over user", body: `{"model":"m","metadata":{"user_id":"meta-user"},"user":"openai-user"}`, want: "meta-user"},
+		{name: "only user", body: `{"model":"m","user":"openai-user"}`, want: "openai-user"},
+		{name: "empty metadata falls back to user", body: `{"model":"m","metadata":{"user_id":""},"user":"openai-user"}`, want: "openai-user"},
+		{name: "neither", body: `{"model":"m"}`, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseGatewayRequest([]byte(tt.body))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, parsed.MetadataUserID)
+
+			parsed, err = ParseOpenAIRequest([]byte(tt.body))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, parsed.MetadataUserID)
+		})
+	}
+}
+
+func TestParseOpenAIRequest(t *testing.T) {
+	body := []byte(`{"model":"gpt-4o","stream":true,"max_tokens":256,"messages":[{"role":"system","content":"be brief"},{"role":"user","content":"hi"}]}`)
+	parsed, err := ParseOpenAIRequest(body)
+	require.NoError(t, err)
+	require.Equal(t, "gpt-4o", parsed.Model)
+	require.True(t, parsed.Stream)
+	require.True(t, parsed.HasSystem)
+	require.Equal(t, "be brief", parsed.System)
+	require.Len(t, parsed.Messages, 1)
+	require.Equal(t, "user", parsed.Messages[0].(map[string]any)["role"])
+	require.Equal(t, body, parsed.Body)
+}
+
+func TestParseOpenAIRequest_NoSystemMessage(t *testing.T) {
+	body := []byte(`{"model":"gpt-4o","messages":[{"role":"user","content":"hi"},{"role":"system","content":"late"}]}`)
+	parsed, err := ParseOpenAIRequest(body)
+	require.NoError(t, err)
+	// 只映射开头的 system 消息
+	require.False(t, parsed.HasSystem)
+	require.Nil(t, parsed.System)
+	require.Len(t, parsed.Messages, 2)
+}
+
+func TestParseOpenAIRequest_InvalidFieldTypes(t *testing.T) {
+	_, err := ParseOpenAIRequest([]byte(`{"model":123}`))
+	require.Error(t, err)
+	_, err = ParseOpenAIRequest([]byte(`{"stream":"true"}`))
+	require.Error(t, err)
+}
+
+func TestParseGatewayRequest_Tools(t *testing.T) {
+	body := []byte(`{"model":"claude-3","tools":[{"name":"get_weather","input_schema":{"type":"object"}},{"type":"web_search_20250305","name":"web_search"},{"type":"custom","name":"mcp","custom":{"input_schema":{"type":"object"}}}]}`)
+	parsed, err := ParseGatewayRequest(body)
+	require.NoError(t, err)
+	require.True(t, parsed.HasTools)
+	require.Equal(t, []GatewayTool{
+		{Name: "get_weather", HasSchema: true},
+		{Name: "web_search"},
+		{Name: "mcp", HasSchema: true},
+	}, parsed.Tools)
+
+	parsed, err = ParseGatewayRequest([]byte(`{"model":"claude-3"}`))
+	require.NoError(t, err)
+	require.False(t, parsed.HasTools)
+	require.Empty(t, parsed.Tools)
+}
+
+func TestParseOpenAIRequest_Tools(t *testing.T) {
+	body := []byte(`{"model":"gpt-4o","tools":[{"type":"function","function":{"name":"lookup","parameters":{"type":"object"}}}],"functions":[{"name":"legacy"}]}`)
+	parsed, err := ParseOpenAIRequest(body)
+	require.NoError(t, err)
+	require.True(t, parsed.HasTools)
+	require.Equal(t, []GatewayTool{
+		{Name: "lookup", HasSchema: true},
+		{Name: "legacy"},
+	}, parsed.Tools)
+}
+
+func TestParseGatewayRequest_InvalidTools(t *testing.T) {
+	for _, body := range []string{
+		`{"model":"claude-3","tools":{"name":"x"}}`,
+		`{"model":"claude-3","tools":["x"]}`,
+		`{"model":"claude-3","tools":[{"name":""}]}`,
+		`{"model":"claude-3","tools":[{"name":"ok"},{"name":1}]}`,
+		`{"model":"claude-3","tools":[{"type":"function","function":"x"}]}`,
+		`{"model":"claude-3","tools":[{"type":"function"}]}`,
+		`{"model":"claude-3","functions":[{"name":1}]}`,
+	} {
+		_, err := ParseGatewayRequest([]byte(body))
+		require.Error(t, err, body)
+	}
+
+	_, err := ParseOpenAIRequest([]byte(`{"model":"gpt-4o","tools":[{"type":"function","function":{"parameters":{}}}]}`))
+	require.Error(t, err)
+	_, err = ParseOpenAIRequest([]byte(`{"model":"gpt-4o","functions":"lookup"}`))
+	require.Error(t, err)
+}
+
+func TestParseGatewayRequest_NonClaudeOpenAIToolsAreSkipped(t *testing.T) {
+	for _, body := range []string{
+		`{"model":"claude-3","tools":null}`,
+		`{"model":"claude-3","tools":[{"input_schema":{}}]}`,
+		`{"model":"gemini-2.5-pro","tools":[{"functionDeclarations":[{"name":"lookup","parameters":{"type":"OBJECT"}}]}]}`,
+		`{"model":"gemini-2.5-pro","tools":[{"googleSearch":{}}]}`,
+	} {
+		parsed, err := ParseGatewayRequest([]byte(body))
+		require.NoError(t, err, body)
+		require.NotNil(t, parsed, body)
+		require.False(t, parsed.HasTools, body)
+		require.Empty(t, parsed.Tools, body)
+	}
+
+	// 跳过的条目不影响同一数组中的其他工具
+	parsed, err := ParseGatewayRequest([]byte(`{"model":"claude-3","tools":[{"input_schema":{}},{"name":"ok"}]}`))
+	require.NoError(t, err)
+	require.True(t, parsed.HasTools)
+	require.Equal(t, []GatewayTool{{Name: "ok"}}, parsed.Tools)
+}
+
+func TestParsedRequest_EstimateInputTokens(t *testing.T) {
+	body := []byte(`{"model":"claude-3","system":[{"type":"text","text":"12345678"}],"messages":[` +
+		`{"role":"user","content":"abcd"},` +
+		`{"role":"assistant","content":[{"type":"text","text":"efgh"},{"type":"tool_use","id":"t1","name":"x","input":{"q":"ignored"}}]},` +
+		`{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":[{"type":"text","text":"ijk"}]}]}]}`)
+	parsed, err := ParseGatewayRequest(body)
+	require.NoError(t, err)
+	require.Equal(t, len(body), parsed.RawByteSize)
+	// 8 + 4 + 4 + 3 = 19 字符 -> 向上取整 5 token
+	require.Equal(t, 5, parsed.EstimateInputTokens())
+
+	openai, err := ParseOpenAIRequest([]byte(`{"model":"gpt-4o","messages":[{"role":"system","content":"你好世界"},{"role":"user","content":[{"type":"text","text":"hi"}]}]}`))
+	require.NoError(t, err)
+	require.Equal(t, 2, openai.EstimateInputTokens())
+
+	var empty *ParsedRequest
+	require.Equal(t, 0, empty.EstimateInputTokens())
+}
+
+func TestReconcileStreamAccept(t *testing.T) {
+	tests := []struct {
+		name       string
+		accept     string
+		stream     bool
+		reconciled bool
+		want       string
+	}{
+		{name: "stream body with json accept", accept: "application/json", stream: true, reconciled: true, want: "text/event-stream"},
+		{name: "non-stream body with sse accept", accept: "text/event-stream", stream: false, reconciled: true, want: "application/json"},
+		{name: "stream body with sse accept", accept: "text/event-stream", stream: true, want: "text/event-stream"},
+		{name: "non-stream body with json accept", accept: "application/json; charset=utf-8", stream: false, want: "application/json; charset=utf-8"},
+		{name: "both accepted", accept: "application/json, text/event-stream", stream: true, want: "application/json, text/event-stream"},
+		{name: "wildcard", accept: "*/*", stream: true, want: "*/*"},
+		{name: "missing", accept: "", stream: true, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.accept != "" {
+				header.Set("Accept", tt.accept)
+			}
+			require.Equal(t, tt.reconciled, ReconcileStreamAccept(header, tt.stream))
+			require.Equal(t, tt.want, header.Get("Accept"))
+		})
+	}
+}
+
 func TestFilterThinkingBlocks(t *testing.T) {
 	containsThinkingBlock := func(body []byte) bool {
 		var req map[string]any
@@ -152,6 +323,54 @@ func TestFilterThinkingBlocks(t *testing.T) {
 	}
 }
 
+func TestFilterThinkingBlocksOpt_KeepSigned(t *testing.T) {
+	// thinking 未启用时，默认删除所有 thinking 块；keepSigned 时保留带有效签名的块
+	input := []byte(`{"model":"claude-3-5-sonnet","messages":[` +
+		`{"role":"assistant","content":[{"type":"thinking","thinking":"signed","signature":"sig-1"},` +
+		`{"type":"thinking","thinking":"unsigned"},` +
+		`{"type":"thinking","thinking":"dummy","signature":"skip_thought_signature_validator"},` +
+		`{"type":"text","text":"answer"}]},` +
+		`{"role":"user","content":[{"type":"thinking","thinking":"user-side","signature":"sig-2"},{"type":"text","text":"next"}]}]}`)
+
+	thinkingTexts := func(body []byte) []string {
+		var req map[string]any
+		require.NoError(t, json.Unmarshal(body, &req))
+		var out []string
+		for _, msg := range req["messages"].([]any) {
+			for _, block := range msg.(map[string]any)["content"].([]any) {
+				blockMap := block.(map[string]any)
+				if blockMap["type"] == "thinking" {
+					out = append(out, blockMap["thinking"].(string))
+				}
+			}
+		}
+		return out
+	}
+
+	require.Empty(t, thinkingTexts(FilterThinkingBlocks(input)))
+	require.Equal(t, thinkingTexts(FilterThinkingBlocks(input)), thinkingTexts(FilterThinkingBlocksOpt(input, false)))
+	require.Equal(t, []string{"signed"}, thinkingTexts(FilterThinkingBlocksOpt(input, true)))
+}
+
+func TestFilterThinkingBlocksWithCount(t *testing.T) {
+	input := []byte(`{"model":"claude-3-5-sonnet","messages":[` +
+		`{"role":"assistant","content":[{"type":"thinking","thinking":"a"},{"type":"redacted_thinking","data":"x"},{"type":"text","text":"answer"}]},` +
+		`{"role":"assistant","content":[{"thinking":"untyped"},{"type":"text","text":"more"}]}]}`)
+	result, removed := FilterThinkingBlocksWithCount(input)
+	require.Equal(t, 3, removed)
+	require.Equal(t, FilterThinkingBlocks(input), result)
+
+	unchanged := []byte(`{"model":"claude-3-5-sonnet","messages":[{"role":"user","content":[{"type":"text","text":"hi"}]}]}`)
+	result, removed = FilterThinkingBlocksWithCount(unchanged)
+	require.Zero(t, removed)
+	require.Equal(t, unchanged, result)
+
+	invalid := []byte(`{"type":"thinking"`)
+	result, removed = FilterThinkingBlocksWithCount(invalid)
+	require.Zero(t, removed)
+	require.Equal(t, invalid, result)
+}
+
 func TestFilterThinkingBlocksForRetry_DisablesThinkingAndPreservesAsText(t *testing.T) {
 	input := []byte(`{
 		"model":"claude-3-5-sonnet-20241022",
@@ -0,0 +1,68 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newUnsupportedModelTestService(action, fallback string) *GatewayService {
+	repo := &mockAccountRepoForPlatform{
+		accounts: []Account{
+			{
+				ID: 1, Platform: PlatformAnthropic, Priority: 1, Status: StatusActive, Schedulable: true,
+				Credentials: map[string]any{"model_mapping": map[string]any{"claude-opus-4": "claude-opus-4"}},
+			},
+		},
+		accountsByID: map[int64]*Account{},
+	}
+	for i := range repo.accounts {
+		repo.accountsByID[repo.accounts[i].ID] = &repo.accounts[i]
+	}
+	cfg := testConfig()
+	cfg.Gateway.UnsupportedModelAction = action
+	cfg.Gateway.UnsupportedModelFallback = fallback
+	return &GatewayService{
+		accountRepo: repo,
+		cache:       &mockGatewayCacheForPlatform{},
+		cfg:         cfg,
+	}
+}
+
+func TestGatewayService_ResolveUnsupportedModel(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("schedule 策略不做处理", func(t *testing.T) {
+		svc := newUnsupportedModelTestService(config.UnsupportedModelActionSchedule, "")
+		parsed := &ParsedRequest{Model: "gpt-4o", Body: []byte(`{"model":"gpt-4o"}`)}
+		require.NoError(t, svc.ResolveUnsupportedModel(ctx, nil, parsed))
+		require.Equal(t, "gpt-4o", parsed.Model)
+	})
+
+	t.Run("reject 策略拒绝不支持的模型", func(t *testing.T) {
+		svc := newUnsupportedModelTestService(config.UnsupportedModelActionReject, "")
+		parsed := &ParsedRequest{Model: "gpt-4o", Body: []byte(`{"model":"gpt-4o"}`)}
+		err := svc.ResolveUnsupportedModel(ctx, nil, parsed)
+		require.True(t, errors.Is(err, ErrNoAccountForModel))
+		require.Contains(t, err.Error(), "gpt-4o")
+	})
+
+	t.Run("reject 策略放行支持的模型", func(t *testing.T) {
+		svc := newUnsupportedModelTestService(config.UnsupportedModelActionReject, "")
+		parsed := &ParsedRequest{Model: "claude-opus-4", Body: []byte(`{"model":"claude-opus-4"}`)}
+		require.NoError(t, svc.ResolveUnsupportedModel(ctx, nil, parsed))
+	})
+
+	t.Run("fallback 策略改写模型", func(t *testing.T) {
+		svc := newUnsupportedModelTestService(config.UnsupportedModelActionFallback, "claude-opus-4")
+		parsed := &ParsedRequest{Model: "gpt-4o", Body: []byte(`{"model":"gpt-4o","stream":true}`)}
+		require.NoError(t, svc.ResolveUnsupportedModel(ctx, nil, parsed))
+		require.Equal(t, "claude-opus-4", parsed.Model)
+		require.JSONEq(t, `{"model":"claude-opus-4","stream":true}`, string(parsed.Body))
+	})
+}
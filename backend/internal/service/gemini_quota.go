@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
 	"strings"
 	"sync"
 	"time"
@@ -117,7 +116,7 @@ func (s *GeminiQuotaService) Policy(ctx context.Context) *GeminiQuotaPolicy {
 			} else {
 				var overridesV1 geminiQuotaOverridesV1
 				if err := json.Unmarshal(raw, &overridesV1); err != nil {
-					log.Printf("gemini quota: parse config policy failed: %v", err)
+					logger().Printf("gemini quota: parse config policy failed: %v", err)
 				} else {
 					policy.ApplyOverrides(overridesV1.Tiers)
 				}
@@ -128,7 +127,7 @@ func (s *GeminiQuotaService) Policy(ctx context.Context) *GeminiQuotaPolicy {
 	if s.settingRepo != nil {
 		value, err := s.settingRepo.GetValue(ctx, SettingKeyGeminiQuotaPolicy)
 		if err != nil && !errors.Is(err, ErrSettingNotFound) {
-			log.Printf("gemini quota: load setting failed: %v", err)
+			logger().Printf("gemini quota: load setting failed: %v", err)
 		} else if strings.TrimSpace(value) != "" {
 			raw := []byte(value)
 			var overridesV2 geminiQuotaOverridesV2
@@ -137,7 +136,7 @@ func (s *GeminiQuotaService) Policy(ctx context.Context) *GeminiQuotaPolicy {
 			} else {
 				var overridesV1 geminiQuotaOverridesV1
 				if err := json.Unmarshal(raw, &overridesV1); err != nil {
-					log.Printf("gemini quota: parse setting failed: %v", err)
+					logger().Printf("gemini quota: parse setting failed: %v", err)
 				} else {
 					policy.ApplyOverrides(overridesV1.Tiers)
 				}
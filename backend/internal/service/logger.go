@@ -0,0 +1,33 @@
+package service
+
+import (
+	"log"
+	"sync/atomic"
+)
+
+// Logger 服务层日志输出接口。
+// 默认使用标准库 log，可通过 SetLogger 替换为结构化/JSON 日志，或在测试中捕获输出。
+type Logger interface {
+	Printf(format string, v ...any)
+	Println(v ...any)
+}
+
+type loggerBox struct{ Logger }
+
+var serviceLogger atomic.Value // loggerBox
+
+// SetLogger 替换服务层日志输出；传入 nil 恢复为标准库 log。
+func SetLogger(l Logger) {
+	if l == nil {
+		l = log.Default()
+	}
+	serviceLogger.Store(loggerBox{l})
+}
+
+// logger 返回当前服务层日志输出
+func logger() Logger {
+	if box, ok := serviceLogger.Load().(loggerBox); ok {
+		return box.Logger
+	}
+	return log.Default()
+}
@@ -0,0 +1,39 @@
+//go:build unit
+
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type captureLogger struct {
+	lines []string
+}
+
+func (l *captureLogger) Printf(format string, v ...any) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func (l *captureLogger) Println(v ...any) {
+	l.lines = append(l.lines, fmt.Sprint(v...))
+}
+
+func TestSetLogger(t *testing.T) {
+	capture := &captureLogger{}
+	SetLogger(capture)
+	t.Cleanup(func() { SetLogger(nil) })
+
+	svc := NewConcurrencyService(&softLimitConcurrencyCache{current: 3})
+	svc.SetSoftLimitPercent(50)
+	_, err := svc.AcquireAccountSlot(t.Context(), 7, 5)
+	require.NoError(t, err)
+
+	require.Len(t, capture.lines, 1)
+	require.Contains(t, capture.lines[0], "account 7 concurrency 3/5 reached soft limit")
+
+	SetLogger(nil)
+	require.NotNil(t, logger())
+}
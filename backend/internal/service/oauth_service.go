@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/pkg/oauth"
@@ -216,7 +215,7 @@ func (s *OAuthService) CookieAuth(ctx context.Context, input *CookieAuthInput) (
 	// Ensure org_uuid is set (from step 1 if not from token response)
 	if tokenInfo.OrgUUID == "" && orgUUID != "" {
 		tokenInfo.OrgUUID = orgUUID
-		log.Printf("[OAuth] Set org_uuid from cookie auth: %s", orgUUID)
+		logger().Printf("[OAuth] Set org_uuid from cookie auth: %s", orgUUID)
 	}
 
 	return tokenInfo, nil
@@ -250,11 +249,11 @@ func (s *OAuthService) exchangeCodeForToken(ctx context.Context, code, codeVerif
 
 	if tokenResp.Organization != nil && tokenResp.Organization.UUID != "" {
 		tokenInfo.OrgUUID = tokenResp.Organization.UUID
-		log.Printf("[OAuth] Got org_uuid: %s", tokenInfo.OrgUUID)
+		logger().Printf("[OAuth] Got org_uuid: %s", tokenInfo.OrgUUID)
 	}
 	if tokenResp.Account != nil && tokenResp.Account.UUID != "" {
 		tokenInfo.AccountUUID = tokenResp.Account.UUID
-		log.Printf("[OAuth] Got account_uuid: %s", tokenInfo.AccountUUID)
+		logger().Printf("[OAuth] Got account_uuid: %s", tokenInfo.AccountUUID)
 	}
 
 	return tokenInfo, nil
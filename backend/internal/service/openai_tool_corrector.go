@@ -3,7 +3,6 @@ package service
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"sync"
 )
 
@@ -140,7 +139,7 @@ func (c *CodexToolCorrector) CorrectToolCallsInSSEData(data string) (string, boo
 	// 序列化回 JSON
 	correctedBytes, err := json.Marshal(payload)
 	if err != nil {
-		log.Printf("[CodexToolCorrector] Failed to marshal corrected data: %v", err)
+		logger().Printf("[CodexToolCorrector] Failed to marshal corrected data: %v", err)
 		return data, false
 	}
 
@@ -219,13 +218,13 @@ func (c *CodexToolCorrector) correctToolParameters(toolName string, functionCall
 				argsMap["workdir"] = workDir
 				delete(argsMap, "work_dir")
 				corrected = true
-				log.Printf("[CodexToolCorrector] Renamed 'work_dir' to 'workdir' in bash tool")
+				logger().Printf("[CodexToolCorrector] Renamed 'work_dir' to 'workdir' in bash tool")
 			}
 		} else {
 			if _, exists := argsMap["work_dir"]; exists {
 				delete(argsMap, "work_dir")
 				corrected = true
-				log.Printf("[CodexToolCorrector] Removed duplicate 'work_dir' parameter from bash tool")
+				logger().Printf("[CodexToolCorrector] Removed duplicate 'work_dir' parameter from bash tool")
 			}
 		}
 
@@ -236,17 +235,17 @@ func (c *CodexToolCorrector) correctToolParameters(toolName string, functionCall
 				argsMap["filePath"] = filePath
 				delete(argsMap, "file_path")
 				corrected = true
-				log.Printf("[CodexToolCorrector] Renamed 'file_path' to 'filePath' in edit tool")
+				logger().Printf("[CodexToolCorrector] Renamed 'file_path' to 'filePath' in edit tool")
 			} else if filePath, exists := argsMap["path"]; exists {
 				argsMap["filePath"] = filePath
 				delete(argsMap, "path")
 				corrected = true
-				log.Printf("[CodexToolCorrector] Renamed 'path' to 'filePath' in edit tool")
+				logger().Printf("[CodexToolCorrector] Renamed 'path' to 'filePath' in edit tool")
 			} else if filePath, exists := argsMap["file"]; exists {
 				argsMap["filePath"] = filePath
 				delete(argsMap, "file")
 				corrected = true
-				log.Printf("[CodexToolCorrector] Renamed 'file' to 'filePath' in edit tool")
+				logger().Printf("[CodexToolCorrector] Renamed 'file' to 'filePath' in edit tool")
 			}
 		}
 
@@ -255,7 +254,7 @@ func (c *CodexToolCorrector) correctToolParameters(toolName string, functionCall
 				argsMap["oldString"] = oldString
 				delete(argsMap, "old_string")
 				corrected = true
-				log.Printf("[CodexToolCorrector] Renamed 'old_string' to 'oldString' in edit tool")
+				logger().Printf("[CodexToolCorrector] Renamed 'old_string' to 'oldString' in edit tool")
 			}
 		}
 
@@ -264,7 +263,7 @@ func (c *CodexToolCorrector) correctToolParameters(toolName string, functionCall
 				argsMap["newString"] = newString
 				delete(argsMap, "new_string")
 				corrected = true
-				log.Printf("[CodexToolCorrector] Renamed 'new_string' to 'newString' in edit tool")
+				logger().Printf("[CodexToolCorrector] Renamed 'new_string' to 'newString' in edit tool")
 			}
 		}
 
@@ -273,7 +272,7 @@ func (c *CodexToolCorrector) correctToolParameters(toolName string, functionCall
 				argsMap["replaceAll"] = replaceAll
 				delete(argsMap, "replace_all")
 				corrected = true
-				log.Printf("[CodexToolCorrector] Renamed 'replace_all' to 'replaceAll' in edit tool")
+				logger().Printf("[CodexToolCorrector] Renamed 'replace_all' to 'replaceAll' in edit tool")
 			}
 		}
 	}
@@ -303,7 +302,7 @@ func (c *CodexToolCorrector) recordCorrection(from, to string) {
 	key := fmt.Sprintf("%s->%s", from, to)
 	c.stats.CorrectionsByTool[key]++
 
-	log.Printf("[CodexToolCorrector] Corrected tool call: %s -> %s (total: %d)",
+	logger().Printf("[CodexToolCorrector] Corrected tool call: %s -> %s (total: %d)",
 		from, to, c.stats.TotalCorrected)
 }
 
@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
 	"strings"
 	"sync"
 	"time"
@@ -57,7 +56,7 @@ type OpsAggregationService struct {
 	cfg         *config.Config
 
 	db          *sql.DB
-	redisClient *redis.Client
+	redisClient redis.UniversalClient
 	instanceID  string
 
 	stopCh    chan struct{}
@@ -75,7 +74,7 @@ func NewOpsAggregationService(
 	opsRepo OpsRepository,
 	settingRepo SettingRepository,
 	db *sql.DB,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 	cfg *config.Config,
 ) *OpsAggregationService {
 	return &OpsAggregationService{
@@ -190,7 +189,7 @@ func (s *OpsAggregationService) aggregateHourly() {
 		latest, ok, err := s.opsRepo.GetLatestHourlyBucketStart(ctxMax)
 		cancelMax()
 		if err != nil {
-			log.Printf("[OpsAggregation][hourly] failed to read latest bucket: %v", err)
+			logger().Printf("[OpsAggregation][hourly] failed to read latest bucket: %v", err)
 		} else if ok {
 			candidate := latest.Add(-opsAggHourlyOverlap)
 			if candidate.After(start) {
@@ -209,7 +208,7 @@ func (s *OpsAggregationService) aggregateHourly() {
 		chunkEnd := minTime(cursor.Add(opsAggHourlyChunk), end)
 		if err := s.opsRepo.UpsertHourlyMetrics(ctx, cursor, chunkEnd); err != nil {
 			aggErr = err
-			log.Printf("[OpsAggregation][hourly] upsert failed (%s..%s): %v", cursor.Format(time.RFC3339), chunkEnd.Format(time.RFC3339), err)
+			logger().Printf("[OpsAggregation][hourly] upsert failed (%s..%s): %v", cursor.Format(time.RFC3339), chunkEnd.Format(time.RFC3339), err)
 			break
 		}
 	}
@@ -288,7 +287,7 @@ func (s *OpsAggregationService) aggregateDaily() {
 		latest, ok, err := s.opsRepo.GetLatestDailyBucketDate(ctxMax)
 		cancelMax()
 		if err != nil {
-			log.Printf("[OpsAggregation][daily] failed to read latest bucket: %v", err)
+			logger().Printf("[OpsAggregation][daily] failed to read latest bucket: %v", err)
 		} else if ok {
 			candidate := latest.Add(-opsAggDailyOverlap)
 			if candidate.After(start) {
@@ -307,7 +306,7 @@ func (s *OpsAggregationService) aggregateDaily() {
 		chunkEnd := minTime(cursor.Add(opsAggDailyChunk), end)
 		if err := s.opsRepo.UpsertDailyMetrics(ctx, cursor, chunkEnd); err != nil {
 			aggErr = err
-			log.Printf("[OpsAggregation][daily] upsert failed (%s..%s): %v", cursor.Format("2006-01-02"), chunkEnd.Format("2006-01-02"), err)
+			logger().Printf("[OpsAggregation][daily] upsert failed (%s..%s): %v", cursor.Format("2006-01-02"), chunkEnd.Format("2006-01-02"), err)
 			break
 		}
 	}
@@ -427,7 +426,7 @@ func (s *OpsAggregationService) maybeLogSkip(prefix string) {
 	if prefix == "" {
 		prefix = "[OpsAggregation]"
 	}
-	log.Printf("%s leader lock held by another instance; skipping", prefix)
+	logger().Printf("%s leader lock held by another instance; skipping", prefix)
 }
 
 func utcFloorToHour(t time.Time) time.Time {
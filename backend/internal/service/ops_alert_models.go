@@ -55,8 +55,9 @@ type OpsAlertEvent struct {
 	FiredAt    time.Time  `json:"fired_at"`
 	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
 
-	EmailSent bool      `json:"email_sent"`
-	CreatedAt time.Time `json:"created_at"`
+	EmailSent   bool      `json:"email_sent"`
+	WebhookSent bool      `json:"webhook_sent"`
+	CreatedAt   time.Time `json:"created_at"`
 }
 
 type OpsAlertSilence struct {
@@ -90,6 +91,6 @@ type OpsAlertEventFilter struct {
 	EndTime   *time.Time
 
 	// Dimensions filters (best-effort).
-	Platform string
+	Platform Platform
 	GroupID  *int64
 }
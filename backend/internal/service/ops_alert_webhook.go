@@ -0,0 +1,115 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/httpclient"
+)
+
+const opsAlertWebhookMaxAttempts = 2
+
+// OpsAlertWebhookPayload is the JSON body posted for a fired alert event.
+type OpsAlertWebhookPayload struct {
+	EventID        int64          `json:"event_id"`
+	RuleID         int64          `json:"rule_id"`
+	RuleName       string         `json:"rule_name"`
+	Severity       string         `json:"severity"`
+	Status         string         `json:"status"`
+	Title          string         `json:"title"`
+	Description    string         `json:"description"`
+	MetricValue    *float64       `json:"metric_value,omitempty"`
+	ThresholdValue *float64       `json:"threshold_value,omitempty"`
+	Dimensions     map[string]any `json:"dimensions,omitempty"`
+	FiredAt        time.Time      `json:"fired_at"`
+}
+
+// WebhookNotifier posts alert events to the configured ops.alert_webhook.url.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier returns nil when no webhook URL is configured.
+func NewWebhookNotifier(cfg *config.Config) *WebhookNotifier {
+	if cfg == nil || strings.TrimSpace(cfg.Ops.AlertWebhook.URL) == "" {
+		return nil
+	}
+	timeout := time.Duration(cfg.Ops.AlertWebhook.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	client, err := httpclient.GetClient(httpclient.Options{
+		Timeout:            timeout,
+		ValidateResolvedIP: cfg.Security.URLAllowlist.Enabled,
+		AllowPrivateHosts:  cfg.Security.URLAllowlist.AllowPrivateHosts,
+	})
+	if err != nil {
+		client = &http.Client{Timeout: timeout}
+	}
+	return &WebhookNotifier{url: strings.TrimSpace(cfg.Ops.AlertWebhook.URL), client: client}
+}
+
+// Notify posts the event once and retries a single time on failure.
+func (n *WebhookNotifier) Notify(ctx context.Context, rule *OpsAlertRule, event *OpsAlertEvent) error {
+	if n == nil || event == nil {
+		return nil
+	}
+	payload := OpsAlertWebhookPayload{
+		EventID:        event.ID,
+		RuleID:         event.RuleID,
+		Severity:       event.Severity,
+		Status:         event.Status,
+		Title:          event.Title,
+		Description:    event.Description,
+		MetricValue:    event.MetricValue,
+		ThresholdValue: event.ThresholdValue,
+		Dimensions:     event.Dimensions,
+		FiredAt:        event.FiredAt,
+	}
+	if rule != nil {
+		payload.RuleName = strings.TrimSpace(rule.Name)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= opsAlertWebhookMaxAttempts; attempt++ {
+		if lastErr = n.post(ctx, body); lastErr == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return lastErr
+}
+
+func (n *WebhookNotifier) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
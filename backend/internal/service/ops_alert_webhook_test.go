@@ -0,0 +1,80 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebhookNotifier(url string) *WebhookNotifier {
+	cfg := &config.Config{}
+	cfg.Ops.AlertWebhook.URL = url
+	cfg.Ops.AlertWebhook.TimeoutSeconds = 2
+	return NewWebhookNotifier(cfg)
+}
+
+func TestNewWebhookNotifier_DisabledWithoutURL(t *testing.T) {
+	require.Nil(t, NewWebhookNotifier(&config.Config{}))
+	require.Nil(t, NewWebhookNotifier(nil))
+
+	var n *WebhookNotifier
+	require.NoError(t, n.Notify(context.Background(), nil, &OpsAlertEvent{}))
+}
+
+func TestWebhookNotifier_RetriesOnceAndPostsPayload(t *testing.T) {
+	var calls atomic.Int32
+	var got OpsAlertWebhookPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	firedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	event := &OpsAlertEvent{
+		ID:             7,
+		RuleID:         3,
+		Severity:       "P1",
+		Status:         OpsAlertStatusFiring,
+		Title:          "P1: error rate",
+		MetricValue:    float64Ptr(12.5),
+		ThresholdValue: float64Ptr(5),
+		FiredAt:        firedAt,
+	}
+	err := newTestWebhookNotifier(srv.URL).Notify(context.Background(), &OpsAlertRule{Name: " error rate "}, event)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), calls.Load())
+	require.Equal(t, int64(7), got.EventID)
+	require.Equal(t, "error rate", got.RuleName)
+	require.Equal(t, 12.5, *got.MetricValue)
+	require.Equal(t, 5.0, *got.ThresholdValue)
+	require.True(t, got.FiredAt.Equal(firedAt))
+}
+
+func TestWebhookNotifier_FailsAfterRetry(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	err := newTestWebhookNotifier(srv.URL).Notify(context.Background(), nil, &OpsAlertEvent{ID: 1})
+	require.Error(t, err)
+	require.Equal(t, int32(opsAlertWebhookMaxAttempts), calls.Load())
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"log"
 	"strings"
 	"sync"
 	"time"
@@ -39,7 +38,7 @@ return 0
 type OpsCleanupService struct {
 	opsRepo     OpsRepository
 	db          *sql.DB
-	redisClient *redis.Client
+	redisClient redis.UniversalClient
 	cfg         *config.Config
 
 	instanceID string
@@ -55,7 +54,7 @@ type OpsCleanupService struct {
 func NewOpsCleanupService(
 	opsRepo OpsRepository,
 	db *sql.DB,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 	cfg *config.Config,
 ) *OpsCleanupService {
 	return &OpsCleanupService{
@@ -75,11 +74,11 @@ func (s *OpsCleanupService) Start() {
 		return
 	}
 	if s.cfg != nil && !s.cfg.Ops.Cleanup.Enabled {
-		log.Printf("[OpsCleanup] not started (disabled)")
+		logger().Printf("[OpsCleanup] not started (disabled)")
 		return
 	}
 	if s.opsRepo == nil || s.db == nil {
-		log.Printf("[OpsCleanup] not started (missing deps)")
+		logger().Printf("[OpsCleanup] not started (missing deps)")
 		return
 	}
 
@@ -99,12 +98,12 @@ func (s *OpsCleanupService) Start() {
 		c := cron.New(cron.WithParser(opsCleanupCronParser), cron.WithLocation(loc))
 		_, err := c.AddFunc(schedule, func() { s.runScheduled() })
 		if err != nil {
-			log.Printf("[OpsCleanup] not started (invalid schedule=%q): %v", schedule, err)
+			logger().Printf("[OpsCleanup] not started (invalid schedule=%q): %v", schedule, err)
 			return
 		}
 		s.cron = c
 		s.cron.Start()
-		log.Printf("[OpsCleanup] started (schedule=%q tz=%s)", schedule, loc.String())
+		logger().Printf("[OpsCleanup] started (schedule=%q tz=%s)", schedule, loc.String())
 	})
 }
 
@@ -118,7 +117,7 @@ func (s *OpsCleanupService) Stop() {
 			select {
 			case <-ctx.Done():
 			case <-time.After(3 * time.Second):
-				log.Printf("[OpsCleanup] cron stop timed out")
+				logger().Printf("[OpsCleanup] cron stop timed out")
 			}
 		}
 	})
@@ -146,11 +145,11 @@ func (s *OpsCleanupService) runScheduled() {
 	counts, err := s.runCleanupOnce(ctx)
 	if err != nil {
 		s.recordHeartbeatError(runAt, time.Since(startedAt), err)
-		log.Printf("[OpsCleanup] cleanup failed: %v", err)
+		logger().Printf("[OpsCleanup] cleanup failed: %v", err)
 		return
 	}
 	s.recordHeartbeatSuccess(runAt, time.Since(startedAt), counts)
-	log.Printf("[OpsCleanup] cleanup complete: %s", counts)
+	logger().Printf("[OpsCleanup] cleanup complete: %s", counts)
 }
 
 type opsCleanupDeletedCounts struct {
@@ -158,17 +157,19 @@ type opsCleanupDeletedCounts struct {
 	retryAttempts int64
 	alertEvents   int64
 	systemMetrics int64
+	concurrency   int64
 	hourlyPreagg  int64
 	dailyPreagg   int64
 }
 
 func (c opsCleanupDeletedCounts) String() string {
 	return fmt.Sprintf(
-		"error_logs=%d retry_attempts=%d alert_events=%d system_metrics=%d hourly_preagg=%d daily_preagg=%d",
+		"error_logs=%d retry_attempts=%d alert_events=%d system_metrics=%d account_concurrency=%d hourly_preagg=%d daily_preagg=%d",
 		c.errorLogs,
 		c.retryAttempts,
 		c.alertEvents,
 		c.systemMetrics,
+		c.concurrency,
 		c.hourlyPreagg,
 		c.dailyPreagg,
 	)
@@ -214,6 +215,12 @@ func (s *OpsCleanupService) runCleanupOnce(ctx context.Context) (opsCleanupDelet
 			return out, err
 		}
 		out.systemMetrics = n
+
+		n, err = deleteOldRowsByID(ctx, s.db, "ops_account_concurrency_samples", "created_at", cutoff, batchSize, false)
+		if err != nil {
+			return out, err
+		}
+		out.concurrency = n
 	}
 
 	// Pre-aggregation tables (hourly/daily).
@@ -315,11 +322,11 @@ func (s *OpsCleanupService) tryAcquireLeaderLock(ctx context.Context) (func(), b
 		}
 		// Redis error: fall back to DB advisory lock.
 		s.warnNoRedisOnce.Do(func() {
-			log.Printf("[OpsCleanup] leader lock SetNX failed; falling back to DB advisory lock: %v", err)
+			logger().Printf("[OpsCleanup] leader lock SetNX failed; falling back to DB advisory lock: %v", err)
 		})
 	} else {
 		s.warnNoRedisOnce.Do(func() {
-			log.Printf("[OpsCleanup] redis not configured; using DB advisory lock")
+			logger().Printf("[OpsCleanup] redis not configured; using DB advisory lock")
 		})
 	}
 
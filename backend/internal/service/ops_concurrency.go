@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"log"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
@@ -42,7 +41,7 @@ func (s *OpsService) listAllAccountsForOps(ctx context.Context, platformFilter s
 
 		page++
 		if page > 10_000 {
-			log.Printf("[Ops] listAllAccountsForOps: aborting after too many pages (platform=%q)", platformFilter)
+			logger().Printf("[Ops] listAllAccountsForOps: aborting after too many pages (platform=%q)", platformFilter)
 			break
 		}
 	}
@@ -86,7 +85,7 @@ func (s *OpsService) getAccountsLoadMapBestEffort(ctx context.Context, accounts
 		part, err := s.concurrencyService.GetAccountsLoadBatch(ctx, batch[i:end])
 		if err != nil {
 			// Best-effort: return zeros rather than failing the ops UI.
-			log.Printf("[Ops] GetAccountsLoadBatch failed: %v", err)
+			logger().Printf("[Ops] GetAccountsLoadBatch failed: %v", err)
 			continue
 		}
 		for k, v := range part {
@@ -97,6 +96,30 @@ func (s *OpsService) getAccountsLoadMapBestEffort(ctx context.Context, accounts
 	return out
 }
 
+// GetConcurrencySoftLimitCrossings returns how many times account slot acquisitions on this
+// instance crossed the configured soft concurrency threshold (see concurrency.soft_limit_percent).
+// The count is in-memory per instance, so with several instances each reports its own value.
+func (s *OpsService) GetConcurrencySoftLimitCrossings() int64 {
+	if s == nil || s.concurrencyService == nil {
+		return 0
+	}
+	return s.concurrencyService.SoftLimitCrossings()
+}
+
+// GetInFlightRequests returns the total number of held account slots across all accounts
+// (including accounts that are no longer listed/schedulable). Best-effort: returns nil on failure.
+func (s *OpsService) GetInFlightRequests(ctx context.Context) *int {
+	if s == nil || s.concurrencyService == nil {
+		return nil
+	}
+	total, err := s.concurrencyService.GetInFlightRequests(ctx)
+	if err != nil {
+		logger().Printf("[Ops] GetInFlightRequests failed: %v", err)
+		return nil
+	}
+	return &total
+}
+
 // GetConcurrencyStats returns real-time concurrency usage aggregated by platform/group/account.
 //
 // Optional filters:
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// opsConcurrencyHistoryMaxRange bounds a single history query (samples are minute-level).
+const opsConcurrencyHistoryMaxRange = 30 * 24 * time.Hour
+
+// OpsAccountConcurrencySample is one per-minute concurrency observation of an account.
+type OpsAccountConcurrencySample struct {
+	AccountID          int64
+	CurrentConcurrency int
+	MaxConcurrency     int
+	WaitingCount       int
+}
+
+type OpsAccountConcurrencyHistoryFilter struct {
+	StartTime time.Time
+	EndTime   time.Time
+
+	// Optional; nil means all accounts.
+	AccountID *int64
+}
+
+// OpsAccountConcurrencyHourlyPoint aggregates the samples of one account within one hour.
+type OpsAccountConcurrencyHourlyPoint struct {
+	AccountID   int64     `json:"account_id"`
+	BucketStart time.Time `json:"bucket_start"`
+
+	PeakConcurrency int     `json:"peak_concurrency"`
+	AvgConcurrency  float64 `json:"avg_concurrency"`
+	PeakWaiting     int     `json:"peak_waiting"`
+	// MaxConcurrency is the configured limit (latest sample in the bucket).
+	MaxConcurrency int `json:"max_concurrency"`
+	SampleCount    int `json:"sample_count"`
+}
+
+// GetAccountConcurrencyHistory returns hourly peak/avg concurrency per account for capacity planning.
+func (s *OpsService) GetAccountConcurrencyHistory(ctx context.Context, filter *OpsAccountConcurrencyHistoryFilter) ([]*OpsAccountConcurrencyHourlyPoint, error) {
+	if err := s.RequireMonitoringEnabled(ctx); err != nil {
+		return nil, err
+	}
+	if s.opsRepo == nil {
+		return nil, infraerrors.ServiceUnavailable("OPS_REPO_UNAVAILABLE", "Ops repository not available")
+	}
+	if filter == nil || filter.StartTime.IsZero() || filter.EndTime.IsZero() {
+		return nil, infraerrors.BadRequest("OPS_TIME_RANGE_REQUIRED", "start_time/end_time are required")
+	}
+	if filter.StartTime.After(filter.EndTime) {
+		return nil, infraerrors.BadRequest("OPS_TIME_RANGE_INVALID", "start_time must be <= end_time")
+	}
+	if filter.EndTime.Sub(filter.StartTime) > opsConcurrencyHistoryMaxRange {
+		return nil, infraerrors.BadRequest("OPS_TIME_RANGE_TOO_LARGE", "time range must be <= 30 days")
+	}
+	return s.opsRepo.GetAccountConcurrencyHistory(ctx, filter)
+}
@@ -0,0 +1,60 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type concurrencySampleAccountRepo struct {
+	AccountRepository
+	accounts []Account
+}
+
+func (r *concurrencySampleAccountRepo) ListSchedulable(ctx context.Context) ([]Account, error) {
+	return r.accounts, nil
+}
+
+type concurrencySampleCache struct {
+	ConcurrencyCache
+	load map[int64]*AccountLoadInfo
+}
+
+func (c *concurrencySampleCache) GetAccountsLoadBatch(ctx context.Context, accounts []AccountWithConcurrency) (map[int64]*AccountLoadInfo, error) {
+	return c.load, nil
+}
+
+func TestOpsMetricsCollector_CollectAccountConcurrency(t *testing.T) {
+	c := &OpsMetricsCollector{
+		accountRepo: &concurrencySampleAccountRepo{accounts: []Account{
+			{ID: 1, Concurrency: 5},
+			{ID: 2, Concurrency: 3},
+		}},
+		concurrencyService: NewConcurrencyService(&concurrencySampleCache{load: map[int64]*AccountLoadInfo{
+			1: {AccountID: 1, CurrentConcurrency: 4, WaitingCount: 2},
+		}}),
+	}
+
+	depth, samples := c.collectAccountConcurrency(context.Background())
+	require.NotNil(t, depth)
+	require.Equal(t, 2, *depth)
+	require.ElementsMatch(t, []OpsAccountConcurrencySample{
+		{AccountID: 1, CurrentConcurrency: 4, MaxConcurrency: 5, WaitingCount: 2},
+		{AccountID: 2, CurrentConcurrency: 0, MaxConcurrency: 3, WaitingCount: 0},
+	}, samples)
+}
+
+func TestGetAccountConcurrencyHistory_ValidatesRange(t *testing.T) {
+	svc := &OpsService{opsRepo: &windowStatsStubRepo{}}
+	now := time.Now()
+
+	_, err := svc.GetAccountConcurrencyHistory(context.Background(), &OpsAccountConcurrencyHistoryFilter{StartTime: now, EndTime: now.Add(-time.Hour)})
+	require.Error(t, err)
+
+	_, err = svc.GetAccountConcurrencyHistory(context.Background(), &OpsAccountConcurrencyHistoryFilter{StartTime: now.Add(-31 * 24 * time.Hour), EndTime: now})
+	require.Error(t, err)
+}
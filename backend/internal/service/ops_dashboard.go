@@ -4,12 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"log"
+	"fmt"
+	"strings"
 	"time"
 
 	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
 )
 
+// opsDashboardOverviewTimeout bounds the shared overview computation, which no longer
+// inherits the deadline of the request that started it.
+const opsDashboardOverviewTimeout = 30 * time.Second
+
 func (s *OpsService) GetDashboardOverview(ctx context.Context, filter *OpsDashboardFilter) (*OpsDashboardOverview, error) {
 	if err := s.RequireMonitoringEnabled(ctx); err != nil {
 		return nil, err
@@ -30,6 +35,43 @@ func (s *OpsService) GetDashboardOverview(ctx context.Context, filter *OpsDashbo
 	// Resolve query mode (requested via query param, or DB default).
 	filter.QueryMode = s.resolveOpsQueryMode(ctx, filter.QueryMode)
 
+	// Concurrent requests for the same range share one computation instead of all hitting the DB.
+	// The shared overview is read-only for callers.
+	ch := s.overviewGroup.DoChan(opsDashboardOverviewFlightKey(filter), func() (any, error) {
+		// Detach from the leader's request: if that client goes away, followers still need the result.
+		computeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), opsDashboardOverviewTimeout)
+		defer cancel()
+		return s.computeDashboardOverview(computeCtx, filter)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		overview, _ := res.Val.(*OpsDashboardOverview)
+		return overview, nil
+	}
+}
+
+// opsDashboardOverviewFlightKey truncates the range to whole seconds: relative ranges ("last 1h")
+// are resolved against time.Now() per request, so exact timestamps would almost never collide.
+func opsDashboardOverviewFlightKey(filter *OpsDashboardFilter) string {
+	groupID := int64(0)
+	if filter.GroupID != nil {
+		groupID = *filter.GroupID
+	}
+	return fmt.Sprintf("%d|%d|%s|%d|%s",
+		filter.StartTime.Truncate(time.Second).Unix(),
+		filter.EndTime.Truncate(time.Second).Unix(),
+		strings.ToLower(strings.TrimSpace(string(filter.Platform))),
+		groupID,
+		filter.QueryMode,
+	)
+}
+
+func (s *OpsService) computeDashboardOverview(ctx context.Context, filter *OpsDashboardFilter) (*OpsDashboardOverview, error) {
 	overview, err := s.opsRepo.GetDashboardOverview(ctx, filter)
 	if err != nil {
 		if errors.Is(err, ErrOpsPreaggregatedNotPopulated) {
@@ -52,13 +94,13 @@ func (s *OpsService) GetDashboardOverview(ctx context.Context, filter *OpsDashbo
 		}
 		overview.SystemMetrics = metrics
 	} else if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		log.Printf("[Ops] GetLatestSystemMetrics failed: %v", err)
+		logger().Printf("[Ops] GetLatestSystemMetrics failed: %v", err)
 	}
 
 	if heartbeats, err := s.opsRepo.ListJobHeartbeats(ctx); err == nil {
 		overview.JobHeartbeats = heartbeats
 	} else {
-		log.Printf("[Ops] ListJobHeartbeats failed: %v", err)
+		logger().Printf("[Ops] ListJobHeartbeats failed: %v", err)
 	}
 
 	overview.HealthScore = computeDashboardHealthScore(time.Now().UTC(), overview)
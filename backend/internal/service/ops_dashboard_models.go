@@ -6,7 +6,7 @@ type OpsDashboardFilter struct {
 	StartTime time.Time
 	EndTime   time.Time
 
-	Platform string
+	Platform Platform
 	GroupID  *int64
 
 	// QueryMode controls whether dashboard queries should use raw logs or pre-aggregated tables.
@@ -0,0 +1,140 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type overviewStubRepo struct {
+	OpsRepository
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *overviewStubRepo) GetDashboardOverview(ctx context.Context, filter *OpsDashboardFilter) (*OpsDashboardOverview, error) {
+	if r.calls.Add(1) == 1 {
+		close(r.started)
+	}
+	<-r.release
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &OpsDashboardOverview{StartTime: filter.StartTime, EndTime: filter.EndTime}, nil
+}
+
+func (r *overviewStubRepo) GetLatestSystemMetrics(ctx context.Context, windowMinutes int) (*OpsSystemMetricsSnapshot, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (r *overviewStubRepo) ListJobHeartbeats(ctx context.Context) ([]*OpsJobHeartbeat, error) {
+	return nil, nil
+}
+
+func TestGetDashboardOverview_SingleflightSharesComputation(t *testing.T) {
+	repo := &overviewStubRepo{started: make(chan struct{}), release: make(chan struct{})}
+	svc := &OpsService{opsRepo: repo}
+
+	end := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	newFilter := func(offset time.Duration) *OpsDashboardFilter {
+		// Same second, different sub-second "now": relative ranges resolved a few ms apart.
+		return &OpsDashboardFilter{StartTime: end.Add(-time.Hour + offset), EndTime: end.Add(offset), QueryMode: OpsQueryModeRaw}
+	}
+
+	const callers = 5
+	results := make([]*OpsDashboardOverview, callers)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		overview, err := svc.GetDashboardOverview(context.Background(), newFilter(0))
+		require.NoError(t, err)
+		results[0] = overview
+	}()
+	<-repo.started
+
+	for i := 1; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			overview, err := svc.GetDashboardOverview(context.Background(), newFilter(time.Duration(i)*time.Millisecond))
+			require.NoError(t, err)
+			results[i] = overview
+		}(i)
+	}
+	// Give followers time to join the in-flight call before releasing it.
+	time.Sleep(50 * time.Millisecond)
+	close(repo.release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), repo.calls.Load())
+	for _, overview := range results {
+		require.Same(t, results[0], overview)
+	}
+}
+
+func TestGetDashboardOverview_LeaderCancelDoesNotFailFollowers(t *testing.T) {
+	repo := &overviewStubRepo{started: make(chan struct{}), release: make(chan struct{})}
+	svc := &OpsService{opsRepo: repo}
+
+	end := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	newFilter := func() *OpsDashboardFilter {
+		return &OpsDashboardFilter{StartTime: end.Add(-time.Hour), EndTime: end, QueryMode: OpsQueryModeRaw}
+	}
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := svc.GetDashboardOverview(leaderCtx, newFilter())
+		leaderErr <- err
+	}()
+	<-repo.started
+
+	type result struct {
+		overview *OpsDashboardOverview
+		err      error
+	}
+	follower := make(chan result, 1)
+	go func() {
+		overview, err := svc.GetDashboardOverview(context.Background(), newFilter())
+		follower <- result{overview, err}
+	}()
+	// Give the follower time to join the in-flight call.
+	time.Sleep(50 * time.Millisecond)
+
+	// The leader's client goes away: it returns immediately, the shared work keeps running.
+	cancelLeader()
+	require.ErrorIs(t, <-leaderErr, context.Canceled)
+
+	close(repo.release)
+	got := <-follower
+	require.NoError(t, got.err)
+	require.NotNil(t, got.overview)
+	require.Equal(t, int32(1), repo.calls.Load())
+}
+
+func TestOpsDashboardOverviewFlightKey(t *testing.T) {
+	end := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	groupID := int64(3)
+	base := &OpsDashboardFilter{StartTime: end.Add(-time.Hour), EndTime: end, QueryMode: OpsQueryModeRaw}
+
+	other := *base
+	other.GroupID = &groupID
+	require.NotEqual(t, opsDashboardOverviewFlightKey(base), opsDashboardOverviewFlightKey(&other))
+
+	other = *base
+	other.Platform = "gemini"
+	require.NotEqual(t, opsDashboardOverviewFlightKey(base), opsDashboardOverviewFlightKey(&other))
+
+	other = *base
+	other.EndTime = end.Add(time.Second)
+	require.NotEqual(t, opsDashboardOverviewFlightKey(base), opsDashboardOverviewFlightKey(&other))
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// OpsErrorLogStream broadcasts newly recorded error logs across instances (Redis Pub/Sub),
+// so the admin WebSocket can push them without polling ListErrorLogs.
+type OpsErrorLogStream interface {
+	PublishErrorLog(ctx context.Context, log *OpsErrorLog) error
+	// SubscribeErrorLogs returns a channel that receives published logs until ctx is done,
+	// after which the channel is closed.
+	SubscribeErrorLogs(ctx context.Context) (<-chan *OpsErrorLog, error)
+}
+
+// SubscribeErrorLogs subscribes to error logs recorded from now on.
+func (s *OpsService) SubscribeErrorLogs(ctx context.Context) (<-chan *OpsErrorLog, error) {
+	if err := s.RequireMonitoringEnabled(ctx); err != nil {
+		return nil, err
+	}
+	if s.errorLogStream == nil {
+		return nil, infraerrors.ServiceUnavailable("OPS_ERROR_STREAM_UNAVAILABLE", "Ops error log stream not available")
+	}
+	return s.errorLogStream.SubscribeErrorLogs(ctx)
+}
+
+// publishErrorLog is best-effort: stream failures never affect error recording.
+func (s *OpsService) publishErrorLog(ctx context.Context, id int64, entry *OpsInsertErrorLogInput) {
+	if s.errorLogStream == nil || entry == nil {
+		return
+	}
+	if err := s.errorLogStream.PublishErrorLog(ctx, opsErrorLogFromInput(id, entry)); err != nil {
+		logger().Printf("[Ops] publish error log failed: %v", err)
+	}
+}
+
+func opsErrorLogFromInput(id int64, entry *OpsInsertErrorLogInput) *OpsErrorLog {
+	return &OpsErrorLog{
+		ID:        id,
+		CreatedAt: entry.CreatedAt,
+
+		Phase:    entry.ErrorPhase,
+		Type:     entry.ErrorType,
+		Owner:    entry.ErrorOwner,
+		Source:   entry.ErrorSource,
+		Severity: entry.Severity,
+
+		StatusCode: entry.StatusCode,
+		Platform:   entry.Platform,
+		Model:      entry.Model,
+
+		IsRetryable: entry.IsRetryable,
+		RetryCount:  entry.RetryCount,
+
+		ClientRequestID: entry.ClientRequestID,
+		RequestID:       entry.RequestID,
+		Message:         entry.ErrorMessage,
+
+		UserID:    entry.UserID,
+		APIKeyID:  entry.APIKeyID,
+		AccountID: entry.AccountID,
+		GroupID:   entry.GroupID,
+
+		ClientIP:    entry.ClientIP,
+		RequestPath: entry.RequestPath,
+		Stream:      entry.Stream,
+	}
+}
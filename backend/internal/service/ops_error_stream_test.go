@@ -0,0 +1,80 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type errorLogInsertStubRepo struct {
+	OpsRepository
+	nextID int64
+	err    error
+}
+
+func (r *errorLogInsertStubRepo) InsertErrorLog(ctx context.Context, input *OpsInsertErrorLogInput) (int64, error) {
+	return r.nextID, r.err
+}
+
+type errorLogStreamStub struct {
+	published []*OpsErrorLog
+}
+
+func (s *errorLogStreamStub) PublishErrorLog(ctx context.Context, log *OpsErrorLog) error {
+	s.published = append(s.published, log)
+	return nil
+}
+
+func (s *errorLogStreamStub) SubscribeErrorLogs(ctx context.Context) (<-chan *OpsErrorLog, error) {
+	return make(chan *OpsErrorLog), nil
+}
+
+func TestOpsService_RecordErrorPublishesToStream(t *testing.T) {
+	stream := &errorLogStreamStub{}
+	svc := &OpsService{opsRepo: &errorLogInsertStubRepo{nextID: 42}, errorLogStream: stream}
+
+	accountID := int64(7)
+	err := svc.RecordError(context.Background(), &OpsInsertErrorLogInput{
+		RequestID:    "req-1",
+		Platform:     PlatformGemini,
+		Model:        "gemini-2.5-pro",
+		StatusCode:   429,
+		AccountID:    &accountID,
+		ErrorMessage: "quota exceeded",
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, stream.published, 1)
+
+	got := stream.published[0]
+	require.Equal(t, int64(42), got.ID)
+	require.Equal(t, "req-1", got.RequestID)
+	require.Equal(t, 429, got.StatusCode)
+	require.Equal(t, "quota exceeded", got.Message)
+	require.Equal(t, &accountID, got.AccountID)
+	// Defaults applied by RecordError are visible to subscribers.
+	require.Equal(t, "internal", got.Phase)
+	require.Equal(t, "api_error", got.Type)
+	require.False(t, got.CreatedAt.IsZero())
+}
+
+func TestOpsService_RecordErrorSkipsPublishOnInsertFailure(t *testing.T) {
+	stream := &errorLogStreamStub{}
+	svc := &OpsService{opsRepo: &errorLogInsertStubRepo{err: errors.New("db down")}, errorLogStream: stream}
+
+	require.Error(t, svc.RecordError(context.Background(), &OpsInsertErrorLogInput{RequestID: "req-1"}, nil))
+	require.Empty(t, stream.published)
+}
+
+func TestOpsService_SubscribeErrorLogsWithoutStream(t *testing.T) {
+	svc := &OpsService{opsRepo: &errorLogInsertStubRepo{}}
+
+	_, err := svc.SubscribeErrorLogs(context.Background())
+	require.Error(t, err)
+
+	// Recording still works when no stream is configured.
+	require.NoError(t, svc.RecordError(context.Background(), &OpsInsertErrorLogInput{RequestID: "req-1"}, nil))
+}
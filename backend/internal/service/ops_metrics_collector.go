@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
 	"math"
 	"os"
 	"runtime"
@@ -48,7 +47,7 @@ type OpsMetricsCollector struct {
 	concurrencyService *ConcurrencyService
 
 	db          *sql.DB
-	redisClient *redis.Client
+	redisClient redis.UniversalClient
 	instanceID  string
 
 	lastCgroupCPUUsageNanos uint64
@@ -68,7 +67,7 @@ func NewOpsMetricsCollector(
 	accountRepo AccountRepository,
 	concurrencyService *ConcurrencyService,
 	db *sql.DB,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 	cfg *config.Config,
 ) *OpsMetricsCollector {
 	return &OpsMetricsCollector{
@@ -204,7 +203,7 @@ func (c *OpsMetricsCollector) collectOnce() {
 			LastError:      &msg,
 			LastDurationMs: &dur,
 		})
-		log.Printf("[OpsMetricsCollector] collect failed: %v", err)
+		logger().Printf("[OpsMetricsCollector] collect failed: %v", err)
 		return
 	}
 
@@ -262,7 +261,7 @@ func (c *OpsMetricsCollector) collectAndPersist(ctx context.Context) error {
 	sys, err := c.collectSystemStats(ctx)
 	if err != nil {
 		// Continue; system stats are best-effort.
-		log.Printf("[OpsMetricsCollector] system stats error: %v", err)
+		logger().Printf("[OpsMetricsCollector] system stats error: %v", err)
 	}
 
 	dbOK := c.checkDB(ctx)
@@ -294,7 +293,7 @@ func (c *OpsMetricsCollector) collectAndPersist(ctx context.Context) error {
 	tps := float64(tokenConsumed) / windowSeconds
 
 	goroutines := runtime.NumGoroutine()
-	concurrencyQueueDepth := c.collectConcurrencyQueueDepth(ctx)
+	concurrencyQueueDepth, concurrencySamples := c.collectAccountConcurrency(ctx)
 
 	input := &OpsInsertSystemMetricsInput{
 		CreatedAt:     windowEnd,
@@ -354,12 +353,24 @@ func (c *OpsMetricsCollector) collectAndPersist(ctx context.Context) error {
 		ConcurrencyQueueDepth: concurrencyQueueDepth,
 	}
 
-	return c.opsRepo.InsertSystemMetrics(ctx, input)
+	if err := c.opsRepo.InsertSystemMetrics(ctx, input); err != nil {
+		return err
+	}
+
+	// Best-effort: per-account samples feed capacity planning only.
+	if len(concurrencySamples) > 0 {
+		if err := c.opsRepo.InsertAccountConcurrencySamples(ctx, windowEnd, concurrencySamples); err != nil {
+			logger().Printf("[OpsMetricsCollector] insert account concurrency samples failed: %v", err)
+		}
+	}
+	return nil
 }
 
-func (c *OpsMetricsCollector) collectConcurrencyQueueDepth(parentCtx context.Context) *int {
+// collectAccountConcurrency samples the load of all schedulable accounts.
+// It returns the total wait-queue depth and the per-account samples (both nil when unavailable).
+func (c *OpsMetricsCollector) collectAccountConcurrency(parentCtx context.Context) (*int, []OpsAccountConcurrencySample) {
 	if c == nil || c.accountRepo == nil || c.concurrencyService == nil {
-		return nil
+		return nil, nil
 	}
 	if parentCtx == nil {
 		parentCtx = context.Background()
@@ -371,11 +382,11 @@ func (c *OpsMetricsCollector) collectConcurrencyQueueDepth(parentCtx context.Con
 
 	accounts, err := c.accountRepo.ListSchedulable(ctx)
 	if err != nil {
-		return nil
+		return nil, nil
 	}
 	if len(accounts) == 0 {
 		zero := 0
-		return &zero
+		return &zero, nil
 	}
 
 	batch := make([]AccountWithConcurrency, 0, len(accounts))
@@ -394,12 +405,25 @@ func (c *OpsMetricsCollector) collectConcurrencyQueueDepth(parentCtx context.Con
 	}
 	if len(batch) == 0 {
 		zero := 0
-		return &zero
+		return &zero, nil
 	}
 
 	loadMap, err := c.concurrencyService.GetAccountsLoadBatch(ctx, batch)
 	if err != nil {
-		return nil
+		return nil, nil
+	}
+
+	samples := make([]OpsAccountConcurrencySample, 0, len(batch))
+	for _, acc := range batch {
+		sample := OpsAccountConcurrencySample{
+			AccountID:      acc.ID,
+			MaxConcurrency: acc.MaxConcurrency,
+		}
+		if info := loadMap[acc.ID]; info != nil {
+			sample.CurrentConcurrency = info.CurrentConcurrency
+			sample.WaitingCount = info.WaitingCount
+		}
+		samples = append(samples, sample)
 	}
 
 	var total int64
@@ -418,7 +442,7 @@ func (c *OpsMetricsCollector) collectConcurrencyQueueDepth(parentCtx context.Con
 		total = maxInt
 	}
 	v := int(total)
-	return &v
+	return &v, samples
 }
 
 type opsCollectedPercentiles struct {
@@ -875,7 +899,7 @@ func (c *OpsMetricsCollector) maybeLogSkip() {
 		return
 	}
 	c.skipLogAt = now
-	log.Printf("[OpsMetricsCollector] leader lock held by another instance; skipping")
+	logger().Printf("[OpsMetricsCollector] leader lock held by another instance; skipping")
 }
 
 func floatToIntPtr(v sql.NullFloat64) *int {
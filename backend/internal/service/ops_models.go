@@ -18,9 +18,9 @@ type OpsErrorLog struct {
 
 	Severity string `json:"severity"`
 
-	StatusCode int    `json:"status_code"`
-	Platform   string `json:"platform"`
-	Model      string `json:"model"`
+	StatusCode int      `json:"status_code"`
+	Platform   Platform `json:"platform"`
+	Model      string   `json:"model"`
 
 	IsRetryable bool `json:"is_retryable"`
 	RetryCount  int  `json:"retry_count"`
@@ -82,7 +82,7 @@ type OpsErrorLogFilter struct {
 	StartTime *time.Time
 	EndTime   *time.Time
 
-	Platform  string
+	Platform  Platform
 	GroupID   *int64
 	AccountID *int64
 
@@ -107,6 +107,38 @@ type OpsErrorLogFilter struct {
 
 	Page     int
 	PageSize int
+
+	// SkipTotal skips the COUNT(*) over the filtered table, which is expensive on large ranges.
+	// Total is then a lower bound: rows before this page plus the rows returned, plus one when
+	// another page exists (enough for "next page" navigation).
+	SkipTotal bool
+}
+
+const (
+	// OpsErrorLogDefaultPageSize is used when the caller does not specify a page size.
+	OpsErrorLogDefaultPageSize = 50
+	// OpsErrorLogMaxPageSize caps a single page so a client can't pull the whole table.
+	OpsErrorLogMaxPageSize = 500
+)
+
+// Normalize returns the effective page and page size for the filter.
+func (f *OpsErrorLogFilter) Normalize() (page, pageSize int) {
+	page = 1
+	pageSize = OpsErrorLogDefaultPageSize
+	if f == nil {
+		return page, pageSize
+	}
+
+	if f.Page > 0 {
+		page = f.Page
+	}
+	if f.PageSize > 0 {
+		pageSize = f.PageSize
+	}
+	if pageSize > OpsErrorLogMaxPageSize {
+		pageSize = OpsErrorLogMaxPageSize
+	}
+	return page, pageSize
 }
 
 type OpsErrorLogList struct {
@@ -0,0 +1,27 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpsErrorLogFilterNormalize(t *testing.T) {
+	var nilFilter *OpsErrorLogFilter
+	page, pageSize := nilFilter.Normalize()
+	require.Equal(t, 1, page)
+	require.Equal(t, OpsErrorLogDefaultPageSize, pageSize)
+
+	page, pageSize = (&OpsErrorLogFilter{}).Normalize()
+	require.Equal(t, 1, page)
+	require.Equal(t, OpsErrorLogDefaultPageSize, pageSize)
+
+	page, pageSize = (&OpsErrorLogFilter{Page: 3, PageSize: 120}).Normalize()
+	require.Equal(t, 3, page)
+	require.Equal(t, 120, pageSize)
+
+	_, pageSize = (&OpsErrorLogFilter{PageSize: 1_000_000}).Normalize()
+	require.Equal(t, OpsErrorLogMaxPageSize, pageSize)
+}
@@ -19,6 +19,7 @@ type OpsRepository interface {
 
 	// Lightweight window stats (for realtime WS / quick sampling).
 	GetWindowStats(ctx context.Context, filter *OpsDashboardFilter) (*OpsWindowStats, error)
+	GetWindowStatsBreakdown(ctx context.Context, filter *OpsDashboardFilter, dimension string) (map[string]*OpsWindowStats, error)
 	// Lightweight realtime traffic summary (for the Ops dashboard header card).
 	GetRealtimeTrafficSummary(ctx context.Context, filter *OpsDashboardFilter) (*OpsRealtimeTrafficSummary, error)
 
@@ -31,6 +32,10 @@ type OpsRepository interface {
 	InsertSystemMetrics(ctx context.Context, input *OpsInsertSystemMetricsInput) error
 	GetLatestSystemMetrics(ctx context.Context, windowMinutes int) (*OpsSystemMetricsSnapshot, error)
 
+	// Per-account concurrency samples (capacity planning).
+	InsertAccountConcurrencySamples(ctx context.Context, createdAt time.Time, samples []OpsAccountConcurrencySample) error
+	GetAccountConcurrencyHistory(ctx context.Context, filter *OpsAccountConcurrencyHistoryFilter) ([]*OpsAccountConcurrencyHourlyPoint, error)
+
 	UpsertJobHeartbeat(ctx context.Context, input *OpsUpsertJobHeartbeatInput) error
 	ListJobHeartbeats(ctx context.Context) ([]*OpsJobHeartbeat, error)
 
@@ -47,6 +52,7 @@ type OpsRepository interface {
 	CreateAlertEvent(ctx context.Context, event *OpsAlertEvent) (*OpsAlertEvent, error)
 	UpdateAlertEventStatus(ctx context.Context, eventID int64, status string, resolvedAt *time.Time) error
 	UpdateAlertEventEmailSent(ctx context.Context, eventID int64, emailSent bool) error
+	UpdateAlertEventWebhookSent(ctx context.Context, eventID int64, webhookSent bool) error
 
 	// Alert silences
 	CreateAlertSilence(ctx context.Context, input *OpsAlertSilence) (*OpsAlertSilence, error)
@@ -69,7 +75,7 @@ type OpsInsertErrorLogInput struct {
 	GroupID   *int64
 	ClientIP  *string
 
-	Platform    string
+	Platform    Platform
 	Model       string
 	RequestPath string
 	Stream      bool
@@ -149,7 +155,7 @@ type OpsInsertSystemMetricsInput struct {
 	CreatedAt     time.Time
 	WindowMinutes int
 
-	Platform *string
+	Platform *Platform
 	GroupID  *int64
 
 	SuccessCount         int64
@@ -253,6 +259,12 @@ type OpsJobHeartbeat struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// Dimensions supported by GetWindowStatsBreakdown.
+const (
+	OpsWindowStatsByPlatform = "platform"
+	OpsWindowStatsByModel    = "model"
+)
+
 type OpsWindowStats struct {
 	StartTime time.Time `json:"start_time"`
 	EndTime   time.Time `json:"end_time"`
@@ -47,7 +47,7 @@ type OpsRequestDetailFilter struct {
 	// kind: success|error|all
 	Kind string
 
-	Platform string
+	Platform Platform
 	GroupID  *int64
 
 	UserID    *int64
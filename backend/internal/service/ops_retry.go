@@ -7,7 +7,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
 	"time"
@@ -324,10 +323,10 @@ func (s *OpsService) retryWithErrorLog(ctx context.Context, requestedByUserID in
 		ResultRequestID:   resultRequestID,
 		ErrorMessage:      updateErrMsg,
 	}); err != nil {
-		log.Printf("[Ops] UpdateRetryAttempt failed: %v", err)
+		logger().Printf("[Ops] UpdateRetryAttempt failed: %v", err)
 	} else if success {
 		if err := s.opsRepo.UpdateErrorResolution(updateCtx, errorID, true, &requestedByUserID, &attemptID, &finishedAt); err != nil {
-			log.Printf("[Ops] UpdateErrorResolution failed: %v", err)
+			logger().Printf("[Ops] UpdateErrorResolution failed: %v", err)
 		}
 	}
 
@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 	"sync"
@@ -39,7 +38,7 @@ type OpsScheduledReportService struct {
 	opsService   *OpsService
 	userService  *UserService
 	emailService *EmailService
-	redisClient  *redis.Client
+	redisClient  redis.UniversalClient
 	cfg          *config.Config
 
 	instanceID string
@@ -59,7 +58,7 @@ func NewOpsScheduledReportService(
 	opsService *OpsService,
 	userService *UserService,
 	emailService *EmailService,
-	redisClient *redis.Client,
+	redisClient redis.UniversalClient,
 	cfg *config.Config,
 ) *OpsScheduledReportService {
 	lockOn := cfg == nil || strings.TrimSpace(cfg.RunMode) != config.RunModeSimple
@@ -263,7 +262,7 @@ func (s *OpsScheduledReportService) listScheduledReports(ctx context.Context, no
 		}
 		sched, err := opsScheduledReportCronParser.Parse(spec)
 		if err != nil {
-			log.Printf("[OpsScheduledReport] invalid cron spec=%q for report=%s: %v", spec, d.kind, err)
+			logger().Printf("[OpsScheduledReport] invalid cron spec=%q for report=%s: %v", spec, d.kind, err)
 			continue
 		}
 
@@ -508,7 +507,7 @@ func buildOpsErrorDigestEmailHTML(title string, start, end time.Time, list *OpsE
 		rows += fmt.Sprintf(
 			"<tr><td>%s</td><td>%s</td><td>%d</td><td>%s</td></tr>",
 			htmlEscape(item.CreatedAt.UTC().Format(time.RFC3339)),
-			htmlEscape(item.Platform),
+			htmlEscape(string(item.Platform)),
 			item.StatusCode,
 			htmlEscape(truncateString(item.Message, 180)),
 		)
@@ -586,7 +585,7 @@ func (s *OpsScheduledReportService) tryAcquireLeaderLock(ctx context.Context) (f
 	}
 	if s.redisClient == nil {
 		s.warnNoRedisOnce.Do(func() {
-			log.Printf("[OpsScheduledReport] redis not configured; running without distributed lock")
+			logger().Printf("[OpsScheduledReport] redis not configured; running without distributed lock")
 		})
 		return nil, true
 	}
@@ -606,7 +605,7 @@ func (s *OpsScheduledReportService) tryAcquireLeaderLock(ctx context.Context) (f
 	ok, err := s.redisClient.SetNX(ctx, key, s.instanceID, ttl).Result()
 	if err != nil {
 		// Prefer fail-closed to avoid duplicate report sends when Redis is flaky.
-		log.Printf("[OpsScheduledReport] leader lock SetNX failed; skipping this cycle: %v", err)
+		logger().Printf("[OpsScheduledReport] leader lock SetNX failed; skipping this cycle: %v", err)
 		return nil, false
 	}
 	if !ok {
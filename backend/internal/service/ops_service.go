@@ -5,12 +5,12 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
-	"log"
 	"strings"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
 	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"golang.org/x/sync/singleflight"
 )
 
 var ErrOpsDisabled = infraerrors.NotFound("OPS_DISABLED", "Ops monitoring is disabled")
@@ -36,6 +36,12 @@ type OpsService struct {
 	openAIGatewayService      *OpenAIGatewayService
 	geminiCompatService       *GeminiMessagesCompatService
 	antigravityGatewayService *AntigravityGatewayService
+
+	// errorLogStream is optional; nil disables realtime error log push.
+	errorLogStream OpsErrorLogStream
+
+	// overviewGroup collapses concurrent dashboard overview computations for the same range.
+	overviewGroup singleflight.Group
 }
 
 func NewOpsService(
@@ -48,6 +54,7 @@ func NewOpsService(
 	openAIGatewayService *OpenAIGatewayService,
 	geminiCompatService *GeminiMessagesCompatService,
 	antigravityGatewayService *AntigravityGatewayService,
+	errorLogStream OpsErrorLogStream,
 ) *OpsService {
 	return &OpsService{
 		opsRepo:     opsRepo,
@@ -61,6 +68,8 @@ func NewOpsService(
 		openAIGatewayService:      openAIGatewayService,
 		geminiCompatService:       geminiCompatService,
 		antigravityGatewayService: antigravityGatewayService,
+
+		errorLogStream: errorLogStream,
 	}
 }
 
@@ -121,6 +130,7 @@ func (s *OpsService) RecordError(ctx context.Context, entry *OpsInsertErrorLogIn
 	if entry.ErrorType == "" {
 		entry.ErrorType = "api_error"
 	}
+	entry.Platform = NormalizePlatform(string(entry.Platform))
 
 	// Sanitize + trim request body (errors only).
 	if len(rawRequestBody) > 0 {
@@ -181,7 +191,7 @@ func (s *OpsService) RecordError(ctx context.Context, entry *OpsInsertErrorLogIn
 			}
 			out := *ev
 
-			out.Platform = strings.TrimSpace(out.Platform)
+			out.Platform = string(NormalizePlatform(out.Platform))
 			out.UpstreamRequestID = truncateString(strings.TrimSpace(out.UpstreamRequestID), 128)
 			out.Kind = truncateString(strings.TrimSpace(out.Kind), 64)
 
@@ -240,11 +250,13 @@ func (s *OpsService) RecordError(ctx context.Context, entry *OpsInsertErrorLogIn
 		entry.UpstreamErrors = nil
 	}
 
-	if _, err := s.opsRepo.InsertErrorLog(ctx, entry); err != nil {
+	id, err := s.opsRepo.InsertErrorLog(ctx, entry)
+	if err != nil {
 		// Never bubble up to gateway; best-effort logging.
-		log.Printf("[Ops] RecordError failed: %v", err)
+		logger().Printf("[Ops] RecordError failed: %v", err)
 		return err
 	}
+	s.publishErrorLog(ctx, id, entry)
 	return nil
 }
 
@@ -252,12 +264,20 @@ func (s *OpsService) GetErrorLogs(ctx context.Context, filter *OpsErrorLogFilter
 	if err := s.RequireMonitoringEnabled(ctx); err != nil {
 		return nil, err
 	}
+	page, pageSize := filter.Normalize()
 	if s.opsRepo == nil {
-		return &OpsErrorLogList{Errors: []*OpsErrorLog{}, Total: 0, Page: 1, PageSize: 20}, nil
+		return &OpsErrorLogList{Errors: []*OpsErrorLog{}, Total: 0, Page: page, PageSize: pageSize}, nil
+	}
+	filterCopy := &OpsErrorLogFilter{}
+	if filter != nil {
+		*filterCopy = *filter
 	}
-	result, err := s.opsRepo.ListErrorLogs(ctx, filter)
+	filterCopy.Page = page
+	filterCopy.PageSize = pageSize
+
+	result, err := s.opsRepo.ListErrorLogs(ctx, filterCopy)
 	if err != nil {
-		log.Printf("[Ops] GetErrorLogs failed: %v", err)
+		logger().Printf("[Ops] GetErrorLogs failed: %v", err)
 		return nil, err
 	}
 
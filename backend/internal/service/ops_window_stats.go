@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
@@ -22,3 +23,102 @@ func (s *OpsService) GetWindowStats(ctx context.Context, startTime, endTime time
 	}
 	return s.opsRepo.GetWindowStats(ctx, filter)
 }
+
+// GetWindowStatsByProvider returns window stats keyed by platform (anthropic/gemini/openai/...).
+// Platforms without traffic in the window are omitted.
+func (s *OpsService) GetWindowStatsByProvider(ctx context.Context, startTime, endTime time.Time) (map[string]*OpsWindowStats, error) {
+	return s.getWindowStatsBreakdown(ctx, startTime, endTime, OpsWindowStatsByPlatform)
+}
+
+// GetWindowStatsByModel returns window stats keyed by requested model.
+// Models without traffic in the window are omitted.
+func (s *OpsService) GetWindowStatsByModel(ctx context.Context, startTime, endTime time.Time) (map[string]*OpsWindowStats, error) {
+	return s.getWindowStatsBreakdown(ctx, startTime, endTime, OpsWindowStatsByModel)
+}
+
+func (s *OpsService) getWindowStatsBreakdown(ctx context.Context, startTime, endTime time.Time, dimension string) (map[string]*OpsWindowStats, error) {
+	if err := s.RequireMonitoringEnabled(ctx); err != nil {
+		return nil, err
+	}
+	if s.opsRepo == nil {
+		return nil, infraerrors.ServiceUnavailable("OPS_REPO_UNAVAILABLE", "Ops repository not available")
+	}
+	filter := &OpsDashboardFilter{
+		StartTime: startTime,
+		EndTime:   endTime,
+	}
+	return s.opsRepo.GetWindowStatsBreakdown(ctx, filter, dimension)
+}
+
+// OpsWindowRates holds success/error rates computed over one trailing window.
+type OpsWindowRates struct {
+	Window    string    `json:"window"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+
+	RequestCount int64 `json:"request_count"`
+	SuccessCount int64 `json:"success_count"`
+	ErrorCount   int64 `json:"error_count"`
+
+	SuccessRate float64 `json:"success_rate"`
+	ErrorRate   float64 `json:"error_rate"`
+}
+
+// DefaultOpsRateWindows are the trailing windows used when the caller does not pick any.
+var DefaultOpsRateWindows = []time.Duration{time.Minute, 5 * time.Minute, time.Hour}
+
+// GetWindowRates computes success/error rates for several trailing windows ending at endTime,
+// so short- and long-term rates can be shown side by side from a single call.
+// Each window reuses GetWindowStats; labels use the ops query format (e.g. "1min", "5min", "1h").
+func (s *OpsService) GetWindowRates(ctx context.Context, endTime time.Time, windows []time.Duration) ([]*OpsWindowRates, error) {
+	if len(windows) == 0 {
+		windows = DefaultOpsRateWindows
+	}
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+
+	out := make([]*OpsWindowRates, 0, len(windows))
+	for _, window := range windows {
+		if window <= 0 {
+			return nil, infraerrors.BadRequest("OPS_WINDOW_INVALID", "window must be positive")
+		}
+		startTime := endTime.Add(-window)
+		stats, err := s.GetWindowStats(ctx, startTime, endTime)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, buildOpsWindowRates(formatOpsWindowLabel(window), stats))
+	}
+	return out, nil
+}
+
+// formatOpsWindowLabel renders a window the way the ops query params spell it:
+// whole hours as "1h", whole minutes as "5min", anything else as a Go duration string.
+func formatOpsWindowLabel(window time.Duration) string {
+	switch {
+	case window%time.Hour == 0:
+		return fmt.Sprintf("%dh", window/time.Hour)
+	case window%time.Minute == 0:
+		return fmt.Sprintf("%dmin", window/time.Minute)
+	default:
+		return window.String()
+	}
+}
+
+func buildOpsWindowRates(label string, stats *OpsWindowStats) *OpsWindowRates {
+	rates := &OpsWindowRates{Window: label}
+	if stats == nil {
+		return rates
+	}
+	rates.StartTime = stats.StartTime
+	rates.EndTime = stats.EndTime
+	rates.SuccessCount = stats.SuccessCount
+	rates.ErrorCount = stats.ErrorCountTotal
+	rates.RequestCount = stats.SuccessCount + stats.ErrorCountTotal
+	if rates.RequestCount > 0 {
+		rates.SuccessRate = float64(rates.SuccessCount) / float64(rates.RequestCount)
+		rates.ErrorRate = float64(rates.ErrorCount) / float64(rates.RequestCount)
+	}
+	return rates
+}
@@ -0,0 +1,90 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type windowStatsStubRepo struct {
+	OpsRepository
+	byWindow map[time.Duration]*OpsWindowStats
+}
+
+func (r *windowStatsStubRepo) GetWindowStats(ctx context.Context, filter *OpsDashboardFilter) (*OpsWindowStats, error) {
+	stats := r.byWindow[filter.EndTime.Sub(filter.StartTime)]
+	if stats == nil {
+		return &OpsWindowStats{StartTime: filter.StartTime, EndTime: filter.EndTime}, nil
+	}
+	out := *stats
+	out.StartTime = filter.StartTime
+	out.EndTime = filter.EndTime
+	return &out, nil
+}
+
+func TestGetWindowRates(t *testing.T) {
+	svc := &OpsService{opsRepo: &windowStatsStubRepo{byWindow: map[time.Duration]*OpsWindowStats{
+		time.Minute:     {SuccessCount: 9, ErrorCountTotal: 1},
+		5 * time.Minute: {SuccessCount: 45, ErrorCountTotal: 5},
+		time.Hour:       {SuccessCount: 0, ErrorCountTotal: 0},
+	}}}
+
+	end := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	rates, err := svc.GetWindowRates(context.Background(), end, nil)
+	require.NoError(t, err)
+	require.Len(t, rates, 3)
+
+	require.Equal(t, "1min", rates[0].Window)
+	require.Equal(t, "5min", rates[1].Window)
+	require.Equal(t, "1h", rates[2].Window)
+
+	require.Equal(t, int64(10), rates[0].RequestCount)
+	require.InDelta(t, 0.9, rates[0].SuccessRate, 1e-9)
+	require.InDelta(t, 0.1, rates[0].ErrorRate, 1e-9)
+	require.Equal(t, end.Add(-time.Minute), rates[0].StartTime)
+
+	require.Equal(t, int64(50), rates[1].RequestCount)
+	require.InDelta(t, 0.1, rates[1].ErrorRate, 1e-9)
+
+	// Empty window: no traffic yields zero rates rather than NaN.
+	require.Zero(t, rates[2].RequestCount)
+	require.Zero(t, rates[2].SuccessRate)
+	require.Zero(t, rates[2].ErrorRate)
+}
+
+func TestGetWindowRates_InvalidWindow(t *testing.T) {
+	svc := &OpsService{opsRepo: &windowStatsStubRepo{}}
+	_, err := svc.GetWindowRates(context.Background(), time.Now(), []time.Duration{0})
+	require.Error(t, err)
+}
+
+type windowStatsBreakdownStubRepo struct {
+	OpsRepository
+	dimensions []string
+}
+
+func (r *windowStatsBreakdownStubRepo) GetWindowStatsBreakdown(ctx context.Context, filter *OpsDashboardFilter, dimension string) (map[string]*OpsWindowStats, error) {
+	r.dimensions = append(r.dimensions, dimension)
+	return map[string]*OpsWindowStats{
+		"gemini": {StartTime: filter.StartTime, EndTime: filter.EndTime, SuccessCount: 3, ErrorCountTotal: 1},
+	}, nil
+}
+
+func TestGetWindowStatsBreakdown(t *testing.T) {
+	repo := &windowStatsBreakdownStubRepo{}
+	svc := &OpsService{opsRepo: repo}
+	end := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	byProvider, err := svc.GetWindowStatsByProvider(context.Background(), end.Add(-time.Minute), end)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), byProvider["gemini"].ErrorCountTotal)
+	require.Equal(t, end.Add(-time.Minute), byProvider["gemini"].StartTime)
+
+	_, err = svc.GetWindowStatsByModel(context.Background(), end.Add(-time.Minute), end)
+	require.NoError(t, err)
+	require.Equal(t, []string{OpsWindowStatsByPlatform, OpsWindowStatsByModel}, repo.dimensions)
+}
@@ -6,7 +6,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -84,12 +83,12 @@ func NewPricingService(cfg *config.Config, remoteClient PricingRemoteClient) *Pr
 func (s *PricingService) Initialize() error {
 	// 确保数据目录存在
 	if err := os.MkdirAll(s.cfg.Pricing.DataDir, 0755); err != nil {
-		log.Printf("[Pricing] Failed to create data directory: %v", err)
+		logger().Printf("[Pricing] Failed to create data directory: %v", err)
 	}
 
 	// 首次加载价格数据
 	if err := s.checkAndUpdatePricing(); err != nil {
-		log.Printf("[Pricing] Initial load failed, using fallback: %v", err)
+		logger().Printf("[Pricing] Initial load failed, using fallback: %v", err)
 		if err := s.useFallbackPricing(); err != nil {
 			return fmt.Errorf("failed to load pricing data: %w", err)
 		}
@@ -98,7 +97,7 @@ func (s *PricingService) Initialize() error {
 	// 启动定时更新
 	s.startUpdateScheduler()
 
-	log.Printf("[Pricing] Service initialized with %d models", len(s.pricingData))
+	logger().Printf("[Pricing] Service initialized with %d models", len(s.pricingData))
 	return nil
 }
 
@@ -106,7 +105,7 @@ func (s *PricingService) Initialize() error {
 func (s *PricingService) Stop() {
 	close(s.stopCh)
 	s.wg.Wait()
-	log.Println("[Pricing] Service stopped")
+	logger().Println("[Pricing] Service stopped")
 }
 
 // startUpdateScheduler 启动定时更新调度器
@@ -127,7 +126,7 @@ func (s *PricingService) startUpdateScheduler() {
 			select {
 			case <-ticker.C:
 				if err := s.syncWithRemote(); err != nil {
-					log.Printf("[Pricing] Sync failed: %v", err)
+					logger().Printf("[Pricing] Sync failed: %v", err)
 				}
 			case <-s.stopCh:
 				return
@@ -135,7 +134,7 @@ func (s *PricingService) startUpdateScheduler() {
 		}
 	}()
 
-	log.Printf("[Pricing] Update scheduler started (check every %v)", hashInterval)
+	logger().Printf("[Pricing] Update scheduler started (check every %v)", hashInterval)
 }
 
 // checkAndUpdatePricing 检查并更新价格数据
@@ -144,7 +143,7 @@ func (s *PricingService) checkAndUpdatePricing() error {
 
 	// 检查本地文件是否存在
 	if _, err := os.Stat(pricingFile); os.IsNotExist(err) {
-		log.Println("[Pricing] Local pricing file not found, downloading...")
+		logger().Println("[Pricing] Local pricing file not found, downloading...")
 		return s.downloadPricingData()
 	}
 
@@ -158,9 +157,9 @@ func (s *PricingService) checkAndUpdatePricing() error {
 	maxAge := time.Duration(s.cfg.Pricing.UpdateIntervalHours) * time.Hour
 
 	if fileAge > maxAge {
-		log.Printf("[Pricing] Local file is %v old, updating...", fileAge.Round(time.Hour))
+		logger().Printf("[Pricing] Local file is %v old, updating...", fileAge.Round(time.Hour))
 		if err := s.downloadPricingData(); err != nil {
-			log.Printf("[Pricing] Download failed, using existing file: %v", err)
+			logger().Printf("[Pricing] Download failed, using existing file: %v", err)
 		}
 	}
 
@@ -175,7 +174,7 @@ func (s *PricingService) syncWithRemote() error {
 	// 计算本地文件哈希
 	localHash, err := s.computeFileHash(pricingFile)
 	if err != nil {
-		log.Printf("[Pricing] Failed to compute local hash: %v", err)
+		logger().Printf("[Pricing] Failed to compute local hash: %v", err)
 		return s.downloadPricingData()
 	}
 
@@ -183,15 +182,15 @@ func (s *PricingService) syncWithRemote() error {
 	if s.cfg.Pricing.HashURL != "" {
 		remoteHash, err := s.fetchRemoteHash()
 		if err != nil {
-			log.Printf("[Pricing] Failed to fetch remote hash: %v", err)
+			logger().Printf("[Pricing] Failed to fetch remote hash: %v", err)
 			return nil // 哈希获取失败不影响正常使用
 		}
 
 		if remoteHash != localHash {
-			log.Println("[Pricing] Remote hash differs, downloading new version...")
+			logger().Println("[Pricing] Remote hash differs, downloading new version...")
 			return s.downloadPricingData()
 		}
-		log.Println("[Pricing] Hash check passed, no update needed")
+		logger().Println("[Pricing] Hash check passed, no update needed")
 		return nil
 	}
 
@@ -205,7 +204,7 @@ func (s *PricingService) syncWithRemote() error {
 	maxAge := time.Duration(s.cfg.Pricing.UpdateIntervalHours) * time.Hour
 
 	if fileAge > maxAge {
-		log.Printf("[Pricing] File is %v old, downloading...", fileAge.Round(time.Hour))
+		logger().Printf("[Pricing] File is %v old, downloading...", fileAge.Round(time.Hour))
 		return s.downloadPricingData()
 	}
 
@@ -218,7 +217,7 @@ func (s *PricingService) downloadPricingData() error {
 	if err != nil {
 		return err
 	}
-	log.Printf("[Pricing] Downloading from %s", remoteURL)
+	logger().Printf("[Pricing] Downloading from %s", remoteURL)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -252,7 +251,7 @@ func (s *PricingService) downloadPricingData() error {
 	// 保存到本地文件
 	pricingFile := s.getPricingFilePath()
 	if err := os.WriteFile(pricingFile, body, 0644); err != nil {
-		log.Printf("[Pricing] Failed to save file: %v", err)
+		logger().Printf("[Pricing] Failed to save file: %v", err)
 	}
 
 	// 保存哈希
@@ -260,7 +259,7 @@ func (s *PricingService) downloadPricingData() error {
 	hashStr := hex.EncodeToString(hash[:])
 	hashFile := s.getHashFilePath()
 	if err := os.WriteFile(hashFile, []byte(hashStr+"\n"), 0644); err != nil {
-		log.Printf("[Pricing] Failed to save hash: %v", err)
+		logger().Printf("[Pricing] Failed to save hash: %v", err)
 	}
 
 	// 更新内存数据
@@ -270,7 +269,7 @@ func (s *PricingService) downloadPricingData() error {
 	s.localHash = hashStr
 	s.mu.Unlock()
 
-	log.Printf("[Pricing] Downloaded %d models successfully", len(data))
+	logger().Printf("[Pricing] Downloaded %d models successfully", len(data))
 	return nil
 }
 
@@ -329,7 +328,7 @@ func (s *PricingService) parsePricingData(body []byte) (map[string]*LiteLLMModel
 	}
 
 	if skipped > 0 {
-		log.Printf("[Pricing] Skipped %d invalid entries", skipped)
+		logger().Printf("[Pricing] Skipped %d invalid entries", skipped)
 	}
 
 	if len(result) == 0 {
@@ -368,7 +367,7 @@ func (s *PricingService) loadPricingData(filePath string) error {
 	}
 	s.mu.Unlock()
 
-	log.Printf("[Pricing] Loaded %d models from %s", len(pricingData), filePath)
+	logger().Printf("[Pricing] Loaded %d models from %s", len(pricingData), filePath)
 	return nil
 }
 
@@ -380,7 +379,7 @@ func (s *PricingService) useFallbackPricing() error {
 		return fmt.Errorf("fallback file not found: %s", fallbackFile)
 	}
 
-	log.Printf("[Pricing] Using fallback file: %s", fallbackFile)
+	logger().Printf("[Pricing] Using fallback file: %s", fallbackFile)
 
 	// 复制到数据目录
 	data, err := os.ReadFile(fallbackFile)
@@ -390,7 +389,7 @@ func (s *PricingService) useFallbackPricing() error {
 
 	pricingFile := s.getPricingFilePath()
 	if err := os.WriteFile(pricingFile, data, 0644); err != nil {
-		log.Printf("[Pricing] Failed to copy fallback: %v", err)
+		logger().Printf("[Pricing] Failed to copy fallback: %v", err)
 	}
 
 	return s.loadPricingData(fallbackFile)
@@ -638,7 +637,7 @@ func (s *PricingService) matchByModelFamily(model string) *LiteLLMModelPricing {
 		for key, pricing := range s.pricingData {
 			keyLower := strings.ToLower(key)
 			if strings.Contains(keyLower, pattern) {
-				log.Printf("[Pricing] Fuzzy matched %s -> %s", model, key)
+				logger().Printf("[Pricing] Fuzzy matched %s -> %s", model, key)
 				return pricing
 			}
 		}
@@ -658,7 +657,7 @@ func (s *PricingService) matchOpenAIModel(model string) *LiteLLMModelPricing {
 
 	for _, variant := range variants {
 		if pricing, ok := s.pricingData[variant]; ok {
-			log.Printf("[Pricing] OpenAI fallback matched %s -> %s", model, variant)
+			logger().Printf("[Pricing] OpenAI fallback matched %s -> %s", model, variant)
 			return pricing
 		}
 	}
@@ -666,7 +665,7 @@ func (s *PricingService) matchOpenAIModel(model string) *LiteLLMModelPricing {
 	// 最终回退到 DefaultTestModel
 	defaultModel := strings.ToLower(openai.DefaultTestModel)
 	if pricing, ok := s.pricingData[defaultModel]; ok {
-		log.Printf("[Pricing] OpenAI fallback to default model %s -> %s", model, defaultModel)
+		logger().Printf("[Pricing] OpenAI fallback to default model %s -> %s", model, defaultModel)
 		return pricing
 	}
 
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
 	"strconv"
 	"sync"
 	"time"
@@ -103,7 +102,7 @@ func (s *SchedulerSnapshotService) ListSchedulableAccounts(ctx context.Context,
 	if s.cache != nil {
 		cached, hit, err := s.cache.GetSnapshot(ctx, bucket)
 		if err != nil {
-			log.Printf("[Scheduler] cache read failed: bucket=%s err=%v", bucket.String(), err)
+			logger().Printf("[Scheduler] cache read failed: bucket=%s err=%v", bucket.String(), err)
 		} else if hit {
 			return derefAccounts(cached), useMixed, nil
 		}
@@ -123,7 +122,7 @@ func (s *SchedulerSnapshotService) ListSchedulableAccounts(ctx context.Context,
 
 	if s.cache != nil {
 		if err := s.cache.SetSnapshot(fallbackCtx, bucket, accounts); err != nil {
-			log.Printf("[Scheduler] cache write failed: bucket=%s err=%v", bucket.String(), err)
+			logger().Printf("[Scheduler] cache write failed: bucket=%s err=%v", bucket.String(), err)
 		}
 	}
 
@@ -137,7 +136,7 @@ func (s *SchedulerSnapshotService) GetAccount(ctx context.Context, accountID int
 	if s.cache != nil {
 		account, err := s.cache.GetAccount(ctx, accountID)
 		if err != nil {
-			log.Printf("[Scheduler] account cache read failed: id=%d err=%v", accountID, err)
+			logger().Printf("[Scheduler] account cache read failed: id=%d err=%v", accountID, err)
 		} else if account != nil {
 			return account, nil
 		}
@@ -159,17 +158,17 @@ func (s *SchedulerSnapshotService) runInitialRebuild() {
 	defer cancel()
 	buckets, err := s.cache.ListBuckets(ctx)
 	if err != nil {
-		log.Printf("[Scheduler] list buckets failed: %v", err)
+		logger().Printf("[Scheduler] list buckets failed: %v", err)
 	}
 	if len(buckets) == 0 {
 		buckets, err = s.defaultBuckets(ctx)
 		if err != nil {
-			log.Printf("[Scheduler] default buckets failed: %v", err)
+			logger().Printf("[Scheduler] default buckets failed: %v", err)
 			return
 		}
 	}
 	if err := s.rebuildBuckets(ctx, buckets, "startup"); err != nil {
-		log.Printf("[Scheduler] rebuild startup failed: %v", err)
+		logger().Printf("[Scheduler] rebuild startup failed: %v", err)
 	}
 }
 
@@ -196,7 +195,7 @@ func (s *SchedulerSnapshotService) runFullRebuildWorker(interval time.Duration)
 		select {
 		case <-ticker.C:
 			if err := s.triggerFullRebuild("interval"); err != nil {
-				log.Printf("[Scheduler] full rebuild failed: %v", err)
+				logger().Printf("[Scheduler] full rebuild failed: %v", err)
 			}
 		case <-s.stopCh:
 			return
@@ -213,13 +212,13 @@ func (s *SchedulerSnapshotService) pollOutbox() {
 
 	watermark, err := s.cache.GetOutboxWatermark(ctx)
 	if err != nil {
-		log.Printf("[Scheduler] outbox watermark read failed: %v", err)
+		logger().Printf("[Scheduler] outbox watermark read failed: %v", err)
 		return
 	}
 
 	events, err := s.outboxRepo.ListAfter(ctx, watermark, 200)
 	if err != nil {
-		log.Printf("[Scheduler] outbox poll failed: %v", err)
+		logger().Printf("[Scheduler] outbox poll failed: %v", err)
 		return
 	}
 	if len(events) == 0 {
@@ -232,14 +231,14 @@ func (s *SchedulerSnapshotService) pollOutbox() {
 		err := s.handleOutboxEvent(eventCtx, event)
 		cancel()
 		if err != nil {
-			log.Printf("[Scheduler] outbox handle failed: id=%d type=%s err=%v", event.ID, event.EventType, err)
+			logger().Printf("[Scheduler] outbox handle failed: id=%d type=%s err=%v", event.ID, event.EventType, err)
 			return
 		}
 	}
 
 	lastID := events[len(events)-1].ID
 	if err := s.cache.SetOutboxWatermark(ctx, lastID); err != nil {
-		log.Printf("[Scheduler] outbox watermark write failed: %v", err)
+		logger().Printf("[Scheduler] outbox watermark write failed: %v", err)
 	} else {
 		watermarkForCheck = lastID
 	}
@@ -436,14 +435,14 @@ func (s *SchedulerSnapshotService) rebuildBucket(ctx context.Context, bucket Sch
 
 	accounts, err := s.loadAccountsFromDB(rebuildCtx, bucket, bucket.Mode == SchedulerModeMixed)
 	if err != nil {
-		log.Printf("[Scheduler] rebuild failed: bucket=%s reason=%s err=%v", bucket.String(), reason, err)
+		logger().Printf("[Scheduler] rebuild failed: bucket=%s reason=%s err=%v", bucket.String(), reason, err)
 		return err
 	}
 	if err := s.cache.SetSnapshot(rebuildCtx, bucket, accounts); err != nil {
-		log.Printf("[Scheduler] rebuild cache failed: bucket=%s reason=%s err=%v", bucket.String(), reason, err)
+		logger().Printf("[Scheduler] rebuild cache failed: bucket=%s reason=%s err=%v", bucket.String(), reason, err)
 		return err
 	}
-	log.Printf("[Scheduler] rebuild ok: bucket=%s reason=%s size=%d", bucket.String(), reason, len(accounts))
+	logger().Printf("[Scheduler] rebuild ok: bucket=%s reason=%s size=%d", bucket.String(), reason, len(accounts))
 	return nil
 }
 
@@ -456,13 +455,13 @@ func (s *SchedulerSnapshotService) triggerFullRebuild(reason string) error {
 
 	buckets, err := s.cache.ListBuckets(ctx)
 	if err != nil {
-		log.Printf("[Scheduler] list buckets failed: %v", err)
+		logger().Printf("[Scheduler] list buckets failed: %v", err)
 		return err
 	}
 	if len(buckets) == 0 {
 		buckets, err = s.defaultBuckets(ctx)
 		if err != nil {
-			log.Printf("[Scheduler] default buckets failed: %v", err)
+			logger().Printf("[Scheduler] default buckets failed: %v", err)
 			return err
 		}
 	}
@@ -476,7 +475,7 @@ func (s *SchedulerSnapshotService) checkOutboxLag(ctx context.Context, oldest Sc
 
 	lag := time.Since(oldest.CreatedAt)
 	if lagSeconds := int(lag.Seconds()); lagSeconds >= s.cfg.Gateway.Scheduling.OutboxLagWarnSeconds && s.cfg.Gateway.Scheduling.OutboxLagWarnSeconds > 0 {
-		log.Printf("[Scheduler] outbox lag warning: %ds", lagSeconds)
+		logger().Printf("[Scheduler] outbox lag warning: %ds", lagSeconds)
 	}
 
 	if s.cfg.Gateway.Scheduling.OutboxLagRebuildSeconds > 0 && int(lag.Seconds()) >= s.cfg.Gateway.Scheduling.OutboxLagRebuildSeconds {
@@ -486,12 +485,12 @@ func (s *SchedulerSnapshotService) checkOutboxLag(ctx context.Context, oldest Sc
 		s.lagMu.Unlock()
 
 		if failures >= s.cfg.Gateway.Scheduling.OutboxLagRebuildFailures {
-			log.Printf("[Scheduler] outbox lag rebuild triggered: lag=%s failures=%d", lag, failures)
+			logger().Printf("[Scheduler] outbox lag rebuild triggered: lag=%s failures=%d", lag, failures)
 			s.lagMu.Lock()
 			s.lagFailures = 0
 			s.lagMu.Unlock()
 			if err := s.triggerFullRebuild("outbox_lag"); err != nil {
-				log.Printf("[Scheduler] outbox lag rebuild failed: %v", err)
+				logger().Printf("[Scheduler] outbox lag rebuild failed: %v", err)
 			}
 		}
 	} else {
@@ -509,9 +508,9 @@ func (s *SchedulerSnapshotService) checkOutboxLag(ctx context.Context, oldest Sc
 		return
 	}
 	if maxID-watermark >= int64(threshold) {
-		log.Printf("[Scheduler] outbox backlog rebuild triggered: backlog=%d", maxID-watermark)
+		logger().Printf("[Scheduler] outbox backlog rebuild triggered: backlog=%d", maxID-watermark)
 		if err := s.triggerFullRebuild("outbox_backlog"); err != nil {
-			log.Printf("[Scheduler] outbox backlog rebuild failed: %v", err)
+			logger().Printf("[Scheduler] outbox backlog rebuild failed: %v", err)
 		}
 	}
 }
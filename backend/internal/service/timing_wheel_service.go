@@ -2,7 +2,6 @@ package service
 
 import (
 	"fmt"
-	"log"
 	"sync"
 	"time"
 
@@ -34,14 +33,14 @@ func NewTimingWheelService() (*TimingWheelService, error) {
 
 // Start starts the timing wheel
 func (s *TimingWheelService) Start() {
-	log.Println("[TimingWheel] Started (auto-start by go-zero)")
+	logger().Println("[TimingWheel] Started (auto-start by go-zero)")
 }
 
 // Stop stops the timing wheel
 func (s *TimingWheelService) Stop() {
 	s.stopOnce.Do(func() {
 		s.tw.Stop()
-		log.Println("[TimingWheel] Stopped")
+		logger().Println("[TimingWheel] Stopped")
 	})
 }
 
@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"sync"
 	"time"
@@ -54,14 +53,14 @@ func NewTokenRefreshService(
 // Start 启动后台刷新服务
 func (s *TokenRefreshService) Start() {
 	if !s.cfg.Enabled {
-		log.Println("[TokenRefresh] Service disabled by configuration")
+		logger().Println("[TokenRefresh] Service disabled by configuration")
 		return
 	}
 
 	s.wg.Add(1)
 	go s.refreshLoop()
 
-	log.Printf("[TokenRefresh] Service started (check every %d minutes, refresh %v hours before expiry)",
+	logger().Printf("[TokenRefresh] Service started (check every %d minutes, refresh %v hours before expiry)",
 		s.cfg.CheckIntervalMinutes, s.cfg.RefreshBeforeExpiryHours)
 }
 
@@ -69,7 +68,7 @@ func (s *TokenRefreshService) Start() {
 func (s *TokenRefreshService) Stop() {
 	close(s.stopCh)
 	s.wg.Wait()
-	log.Println("[TokenRefresh] Service stopped")
+	logger().Println("[TokenRefresh] Service stopped")
 }
 
 // refreshLoop 刷新循环
@@ -85,13 +84,24 @@ func (s *TokenRefreshService) refreshLoop() {
 	ticker := time.NewTicker(checkInterval)
 	defer ticker.Stop()
 
+	// stopCh 关闭时取消 ctx，使进行中的刷新周期（含重试退避与上游请求）及时中断
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
 	// 启动时立即执行一次检查
-	s.processRefresh()
+	s.processRefresh(ctx)
 
 	for {
 		select {
 		case <-ticker.C:
-			s.processRefresh()
+			s.processRefresh(ctx)
 		case <-s.stopCh:
 			return
 		}
@@ -99,8 +109,8 @@ func (s *TokenRefreshService) refreshLoop() {
 }
 
 // processRefresh 执行一次刷新检查
-func (s *TokenRefreshService) processRefresh() {
-	ctx := context.Background()
+// ctx 被取消（Stop）时在账号之间中断本周期，剩余账号留待下次启动处理
+func (s *TokenRefreshService) processRefresh(ctx context.Context) {
 
 	// 计算刷新窗口
 	refreshWindow := time.Duration(s.cfg.RefreshBeforeExpiryHours * float64(time.Hour))
@@ -108,7 +118,7 @@ func (s *TokenRefreshService) processRefresh() {
 	// 获取所有active状态的账号
 	accounts, err := s.listActiveAccounts(ctx)
 	if err != nil {
-		log.Printf("[TokenRefresh] Failed to list accounts: %v", err)
+		logger().Printf("[TokenRefresh] Failed to list accounts: %v", err)
 		return
 	}
 
@@ -118,6 +128,10 @@ func (s *TokenRefreshService) processRefresh() {
 	refreshed, failed := 0, 0
 
 	for i := range accounts {
+		if ctx.Err() != nil {
+			logger().Printf("[TokenRefresh] Cycle aborted: %v (processed %d/%d accounts)", ctx.Err(), i, totalAccounts)
+			return
+		}
 		account := &accounts[i]
 
 		// 遍历所有刷新器，找到能处理此账号的
@@ -137,10 +151,13 @@ func (s *TokenRefreshService) processRefresh() {
 
 			// 执行刷新
 			if err := s.refreshWithRetry(ctx, account, refresher); err != nil {
-				log.Printf("[TokenRefresh] Account %d (%s) failed: %v", account.ID, account.Name, err)
+				if ctx.Err() != nil {
+					break
+				}
+				logger().Printf("[TokenRefresh] Account %d (%s) failed: %v", account.ID, account.Name, err)
 				failed++
 			} else {
-				log.Printf("[TokenRefresh] Account %d (%s) refreshed successfully", account.ID, account.Name)
+				logger().Printf("[TokenRefresh] Account %d (%s) refreshed successfully", account.ID, account.Name)
 				refreshed++
 			}
 
@@ -150,7 +167,7 @@ func (s *TokenRefreshService) processRefresh() {
 	}
 
 	// 始终打印周期日志，便于跟踪服务运行状态
-	log.Printf("[TokenRefresh] Cycle complete: total=%d, oauth=%d, needs_refresh=%d, refreshed=%d, failed=%d",
+	logger().Printf("[TokenRefresh] Cycle complete: total=%d, oauth=%d, needs_refresh=%d, refreshed=%d, failed=%d",
 		totalAccounts, oauthAccounts, needsRefresh, refreshed, failed)
 }
 
@@ -175,9 +192,9 @@ func (s *TokenRefreshService) refreshWithRetry(ctx context.Context, account *Acc
 			// 对所有 OAuth 账号调用缓存失效（InvalidateToken 内部根据平台判断是否需要处理）
 			if s.cacheInvalidator != nil && account.Type == AccountTypeOAuth {
 				if err := s.cacheInvalidator.InvalidateToken(ctx, account); err != nil {
-					log.Printf("[TokenRefresh] Failed to invalidate token cache for account %d: %v", account.ID, err)
+					logger().Printf("[TokenRefresh] Failed to invalidate token cache for account %d: %v", account.ID, err)
 				} else {
-					log.Printf("[TokenRefresh] Token cache invalidated for account %d", account.ID)
+					logger().Printf("[TokenRefresh] Token cache invalidated for account %d", account.ID)
 				}
 			}
 			return nil
@@ -187,31 +204,42 @@ func (s *TokenRefreshService) refreshWithRetry(ctx context.Context, account *Acc
 		if account.Platform == PlatformAntigravity && isNonRetryableRefreshError(err) {
 			errorMsg := fmt.Sprintf("Token refresh failed (non-retryable): %v", err)
 			if setErr := s.accountRepo.SetError(ctx, account.ID, errorMsg); setErr != nil {
-				log.Printf("[TokenRefresh] Failed to set error status for account %d: %v", account.ID, setErr)
+				logger().Printf("[TokenRefresh] Failed to set error status for account %d: %v", account.ID, setErr)
 			}
 			return err
 		}
 
+		// 服务停止导致的失败不是账号问题，直接返回，不标记 error
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
 		lastErr = err
-		log.Printf("[TokenRefresh] Account %d attempt %d/%d failed: %v",
+		logger().Printf("[TokenRefresh] Account %d attempt %d/%d failed: %v",
 			account.ID, attempt, s.cfg.MaxRetries, err)
 
 		// 如果还有重试机会，等待后重试
 		if attempt < s.cfg.MaxRetries {
 			// 指数退避：2^(attempt-1) * baseSeconds
 			backoff := time.Duration(s.cfg.RetryBackoffSeconds) * time.Second * time.Duration(1<<(attempt-1))
-			time.Sleep(backoff)
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
 		}
 	}
 
 	// Antigravity 账户：其他错误仅记录日志，不标记 error（可能是临时网络问题）
 	// 其他平台账户：重试失败后标记 error
 	if account.Platform == PlatformAntigravity {
-		log.Printf("[TokenRefresh] Account %d: refresh failed after %d retries: %v", account.ID, s.cfg.MaxRetries, lastErr)
+		logger().Printf("[TokenRefresh] Account %d: refresh failed after %d retries: %v", account.ID, s.cfg.MaxRetries, lastErr)
 	} else {
 		errorMsg := fmt.Sprintf("Token refresh failed after %d retries: %v", s.cfg.MaxRetries, lastErr)
 		if err := s.accountRepo.SetError(ctx, account.ID, errorMsg); err != nil {
-			log.Printf("[TokenRefresh] Failed to set error status for account %d: %v", account.ID, err)
+			logger().Printf("[TokenRefresh] Failed to set error status for account %d: %v", account.ID, err)
 		}
 	}
 
@@ -359,3 +359,73 @@ func TestIsNonRetryableRefreshError(t *testing.T) {
 		})
 	}
 }
+
+type tokenRefreshListRepo struct {
+	tokenRefreshAccountRepo
+	accounts []Account
+}
+
+func (r *tokenRefreshListRepo) ListActive(ctx context.Context) ([]Account, error) {
+	return r.accounts, nil
+}
+
+// blockingRefresherStub 模拟慢上游：Refresh 阻塞直到 ctx 取消
+type blockingRefresherStub struct {
+	started chan struct{}
+	calls   int
+}
+
+func (r *blockingRefresherStub) CanRefresh(account *Account) bool { return true }
+
+func (r *blockingRefresherStub) NeedsRefresh(account *Account, refreshWindowDuration time.Duration) bool {
+	return true
+}
+
+func (r *blockingRefresherStub) Refresh(ctx context.Context, account *Account) (map[string]any, error) {
+	r.calls++
+	if r.calls == 1 {
+		close(r.started)
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestTokenRefreshService_StopInterruptsRefreshCycle(t *testing.T) {
+	accounts := make([]Account, 50)
+	for i := range accounts {
+		accounts[i] = Account{ID: int64(i + 1), Platform: PlatformGemini, Type: AccountTypeOAuth}
+	}
+	repo := &tokenRefreshListRepo{accounts: accounts}
+	cfg := &config.Config{
+		TokenRefresh: config.TokenRefreshConfig{
+			Enabled:              true,
+			CheckIntervalMinutes: 60,
+			MaxRetries:           3,
+			RetryBackoffSeconds:  60,
+		},
+	}
+	service := NewTokenRefreshService(repo, nil, nil, nil, nil, nil, cfg)
+	refresher := &blockingRefresherStub{started: make(chan struct{})}
+	service.refreshers = []TokenRefresher{refresher}
+
+	service.Start()
+	select {
+	case <-refresher.started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("refresh cycle did not start")
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		service.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop did not interrupt the in-progress refresh cycle")
+	}
+	require.Equal(t, 1, refresher.calls, "remaining accounts should be skipped after Stop")
+	require.Zero(t, repo.setErrorCalls, "cancellation must not mark accounts as errored")
+}
@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
 
 	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
 )
@@ -47,36 +46,36 @@ func NewTurnstileService(settingService *SettingService, verifier TurnstileVerif
 func (s *TurnstileService) VerifyToken(ctx context.Context, token string, remoteIP string) error {
 	// 检查是否启用 Turnstile
 	if !s.settingService.IsTurnstileEnabled(ctx) {
-		log.Println("[Turnstile] Disabled, skipping verification")
+		logger().Println("[Turnstile] Disabled, skipping verification")
 		return nil
 	}
 
 	// 获取 Secret Key
 	secretKey := s.settingService.GetTurnstileSecretKey(ctx)
 	if secretKey == "" {
-		log.Println("[Turnstile] Secret key not configured")
+		logger().Println("[Turnstile] Secret key not configured")
 		return ErrTurnstileNotConfigured
 	}
 
 	// 如果 token 为空，返回错误
 	if token == "" {
-		log.Println("[Turnstile] Token is empty")
+		logger().Println("[Turnstile] Token is empty")
 		return ErrTurnstileVerificationFailed
 	}
 
-	log.Printf("[Turnstile] Verifying token for IP: %s", remoteIP)
+	logger().Printf("[Turnstile] Verifying token for IP: %s", remoteIP)
 	result, err := s.verifier.VerifyToken(ctx, secretKey, token, remoteIP)
 	if err != nil {
-		log.Printf("[Turnstile] Request failed: %v", err)
+		logger().Printf("[Turnstile] Request failed: %v", err)
 		return fmt.Errorf("send request: %w", err)
 	}
 
 	if !result.Success {
-		log.Printf("[Turnstile] Verification failed, error codes: %v", result.ErrorCodes)
+		logger().Printf("[Turnstile] Verification failed, error codes: %v", result.ErrorCodes)
 		return ErrTurnstileVerificationFailed
 	}
 
-	log.Println("[Turnstile] Verification successful")
+	logger().Println("[Turnstile] Verification successful")
 	return nil
 }
 
@@ -0,0 +1,17 @@
+-- Make ops_system_metrics snapshots idempotent per (minute, window, dimension).
+--
+-- The collector truncates created_at to the minute; when it runs on more than one
+-- instance (or retries), the same snapshot could be inserted multiple times.
+-- A unique index lets InsertSystemMetrics use ON CONFLICT DO NOTHING.
+
+-- Remove existing duplicates, keeping the earliest row of each group.
+DELETE FROM ops_system_metrics m
+USING ops_system_metrics d
+WHERE m.created_at = d.created_at
+  AND m.window_minutes = d.window_minutes
+  AND COALESCE(m.platform, '') = COALESCE(d.platform, '')
+  AND COALESCE(m.group_id, 0) = COALESCE(d.group_id, 0)
+  AND m.id > d.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_ops_system_metrics_snapshot
+    ON ops_system_metrics (created_at, window_minutes, COALESCE(platform, ''), COALESCE(group_id, 0));
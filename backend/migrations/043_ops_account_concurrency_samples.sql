@@ -0,0 +1,20 @@
+-- Per-account concurrency samples for capacity planning.
+--
+-- The ops metrics collector (leader only) records each schedulable account's
+-- in-flight concurrency once per minute; the admin API aggregates the samples
+-- into hourly max/avg so operators can right-size accounts.concurrency.
+
+CREATE TABLE IF NOT EXISTS ops_account_concurrency_samples (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL,
+    account_id BIGINT NOT NULL,
+    current_concurrency INT NOT NULL DEFAULT 0,
+    max_concurrency INT NOT NULL DEFAULT 0,
+    waiting_count INT NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_ops_account_concurrency_samples_account_time
+    ON ops_account_concurrency_samples (account_id, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_ops_account_concurrency_samples_created_at
+    ON ops_account_concurrency_samples (created_at);
@@ -0,0 +1,4 @@
+-- Track webhook delivery for alert events (mirrors email_sent).
+
+ALTER TABLE ops_alert_events
+    ADD COLUMN IF NOT EXISTS webhook_sent BOOLEAN NOT NULL DEFAULT false;
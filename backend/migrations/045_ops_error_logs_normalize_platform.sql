@@ -0,0 +1,15 @@
+-- Normalize legacy platform values stored in ops_error_logs to canonical platform values.
+-- New rows are normalized by OpsService.RecordError (see service.platformAliases) and ops filters
+-- only match canonical values, so historical rows are rewritten to stay visible.
+
+UPDATE ops_error_logs
+SET platform = CASE LOWER(TRIM(platform))
+        WHEN 'claude' THEN 'anthropic'
+        ELSE 'gemini'
+    END
+WHERE LOWER(TRIM(platform)) IN ('claude', 'ai_studio', 'aistudio', 'code_assist', 'google_one');
+
+UPDATE ops_error_logs
+SET platform = LOWER(TRIM(platform))
+WHERE LOWER(TRIM(platform)) IN ('anthropic', 'openai', 'gemini', 'antigravity')
+  AND platform <> LOWER(TRIM(platform));